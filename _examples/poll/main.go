@@ -27,8 +27,10 @@ import (
 	"golang.org/x/crypto/ssh/terminal"
 
 	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 	"syscall"
 	"time"
@@ -54,24 +56,41 @@ func main() {
 	fmt.Print("\n")
 
 	fmt.Print("Polling for new reports and activity:\n")
-	errChan, reportsChan, activitiesChan := polling.Start(
+	poller := polling.NewPoller(
 		h1.NewClient(tp.Client()),
 		h1.ReportListFilter{
 			Program: []string{strings.TrimSpace(program)},
 		},
 		time.Second*20,
 		time.Second*60,
+		10,
 	)
 
-	for {
-		select {
-		case err := <-errChan:
-			panic(err)
-		case report := <-reportsChan:
-			fmt.Printf("New Report [%s]: %s\n", *report.ID, *report.Title)
-		case activity := <-activitiesChan:
-			fmt.Printf("New Activity [%s/%s/%s]: %s\n", *activity.Report().ID, *activity.ID, *activity.Type, *activity.Message)
-		}
-	}
+	// Stop polling on interrupt
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		<-signals
+		poller.Stop()
+	}()
+	go poller.Run(context.Background())
+
+	var handlers polling.Handlers
+	handlers.OnError(func(err error) {
+		fmt.Printf("Error: %s\n", err)
+	})
+	handlers.OnReportCreated(func(report *h1.Report) {
+		fmt.Printf("New Report [%s]: %s\n", *report.ID, *report.Title)
+	})
+	handlers.OnStateChanged(func(report *h1.Report, activity *h1.Activity, state string) {
+		fmt.Printf("Report [%s] is now %s\n", *report.ID, state)
+	})
+	handlers.OnBountyAwarded(func(report *h1.Report, activity *h1.Activity, bounty *h1.ActivityBountyAwarded) {
+		fmt.Printf("Bounty awarded on Report [%s]: %s\n", *report.ID, *bounty.BountyAmount)
+	})
+	handlers.OnCommentAdded(func(report *h1.Report, activity *h1.Activity) {
+		fmt.Printf("New Comment [%s/%s]: %s\n", *report.ID, *activity.ID, *activity.Message)
+	})
+	handlers.Serve(poller.Events())
 
 }
@@ -0,0 +1,251 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package autoresolve resolves HackerOne reports once the issues tracking them are fixed.
+package autoresolve
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/polling"
+	"github.com/uber-go/hackeroni/trackersync"
+
+	"bytes"
+	"context"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Action represent the possible outcomes of checking a report
+const (
+	ActionResolved      string = "resolved"       // The issue was fixed and the report was resolved
+	ActionPendingBounty string = "pending-bounty" // The issue was fixed but the report was left open as it has a pending bounty
+	ActionUnchanged     string = "unchanged"      // The issue isn't fixed yet
+)
+
+// defaultInterval is how often reports are checked when NewResolver is given no interval
+const defaultInterval = 10 * time.Minute
+
+// DefaultMessage is posted when resolving a report if the matching rule has no message
+var DefaultMessage = template.Must(template.New("message").Parse(
+	"The issue tracking this report ({{.Issue.Key}}) has been fixed, so we're marking it as resolved. Thank you for your report!",
+))
+
+// DefaultRules resolve reports when their issue's resolution is "Fixed" or "Done"
+var DefaultRules = []Rule{
+	{Resolutions: []string{"Fixed", "Done"}},
+}
+
+// Tracker is where the issues reports are linked to live. trackersync.Tracker implementations satisfy it.
+type Tracker interface {
+	Issue(key string) (*trackersync.Issue, error)
+}
+
+// Rule describes when an issue counts as fixed and what to tell the reporter. Statuses and resolutions are compared case-insensitively and a rule without either never matches.
+type Rule struct {
+	Statuses    []string           // The issue statuses which count as fixed
+	Resolutions []string           // The issue resolutions which count as fixed
+	Message     *template.Template // Executed with MessageData to create the message posted with the resolution, defaults to DefaultMessage
+}
+
+// MessageData is what a Rule's message is executed with
+type MessageData struct {
+	Report *h1.Report
+	Issue  *trackersync.Issue
+}
+
+// Result is the outcome of checking a report
+type Result struct {
+	Action string
+	Report *h1.Report
+	Issue  *trackersync.Issue
+	Rule   *Rule // The rule which matched, nil if the action is ActionUnchanged
+}
+
+// Matches returns whether the rule considers an issue fixed
+func (r *Rule) Matches(issue *trackersync.Issue) bool {
+	return containsFold(r.Statuses, issue.Status) || containsFold(r.Resolutions, issue.Resolution)
+}
+
+// containsFold returns whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolver resolves open reports whose issue tracker reference matches a rule
+type Resolver struct {
+	Client   *h1.Client
+	Tracker  Tracker
+	Rules    []Rule              // Checked in order, the first which matches is used. Defaults to DefaultRules
+	Filter   h1.ReportListFilter // The reports to check, defaults to those which are new, triaged or need more info
+	Interval time.Duration       // How often Run checks reports, must be positive
+	OnResult func(Result)        // Called with the result of checking each report with an issue tracker reference
+	OnError  func(err error)     // Called when reports can't be checked, errors are dropped if nil
+
+	loop *polling.Loop
+}
+
+// NewResolver creates a Resolver using DefaultRules. An interval of zero or less defaults to 10 minutes
+func NewResolver(client *h1.Client, tracker Tracker, filter h1.ReportListFilter, interval time.Duration) *Resolver {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	return &Resolver{
+		Client:   client,
+		Tracker:  tracker,
+		Rules:    DefaultRules,
+		Filter:   filter,
+		Interval: interval,
+		loop:     polling.NewLoop("resolver"),
+	}
+}
+
+// Run checks reports immediately and then at the interval until the context is done or Stop is called.
+// It returns the context's error if the context ended it, and nil if Stop did. A Resolver can only be run once.
+func (r *Resolver) Run(ctx context.Context) error {
+	return r.loop.Run(ctx, r.Interval, r.checkAll, nil)
+}
+
+// Stop stops the resolver and waits for Run to return. It is safe to call more than once
+func (r *Resolver) Stop() {
+	r.loop.Stop()
+}
+
+// checkAll lists the reports matching the filter and checks each one with an issue tracker reference
+func (r *Resolver) checkAll(ctx context.Context) {
+	filter := r.Filter
+	if len(filter.State) == 0 {
+		filter.State = []string{h1.ReportStateNew, h1.ReportStateTriaged, h1.ReportStateNeedsMoreInfo}
+	}
+
+	allReports, err := polling.ListReports(r.Client, filter)
+	if err != nil {
+		r.error(err)
+		return
+	}
+
+	for _, listed := range allReports {
+		if ctx.Err() != nil {
+			return
+		}
+		if listed.IssueTrackerReferenceID == nil || *listed.IssueTrackerReferenceID == "" {
+			continue
+		}
+		// Listed reports don't include activities, which are needed to find pending bounties
+		report, _, err := r.Client.Report.Get(*listed.ID)
+		if err != nil {
+			r.error(err)
+			continue
+		}
+		result, err := r.Check(report)
+		if err != nil {
+			r.error(err)
+			continue
+		}
+		if r.OnResult != nil {
+			r.OnResult(result)
+		}
+	}
+}
+
+// error passes an error to OnError if it's set
+func (r *Resolver) error(err error) {
+	if r.OnError != nil {
+		r.OnError(err)
+	}
+}
+
+// Check looks up the report's issue and resolves the report if a rule matches and it has no pending bounty. The report should be fetched with Report.Get so that it includes its activities.
+func (r *Resolver) Check(report *h1.Report) (Result, error) {
+	result := Result{
+		Action: ActionUnchanged,
+		Report: report,
+	}
+	if report.IssueTrackerReferenceID == nil || *report.IssueTrackerReferenceID == "" {
+		return result, nil
+	}
+
+	issue, err := r.Tracker.Issue(*report.IssueTrackerReferenceID)
+	if err != nil {
+		return result, err
+	}
+	result.Issue = issue
+
+	rules := r.Rules
+	if rules == nil {
+		rules = DefaultRules
+	}
+	for idx := range rules {
+		if rules[idx].Matches(issue) {
+			result.Rule = &rules[idx]
+			break
+		}
+	}
+	if result.Rule == nil {
+		return result, nil
+	}
+
+	if PendingBounty(report) {
+		result.Action = ActionPendingBounty
+		return result, nil
+	}
+
+	message := result.Rule.Message
+	if message == nil {
+		message = DefaultMessage
+	}
+	var buf bytes.Buffer
+	if err := message.Execute(&buf, MessageData{Report: report, Issue: issue}); err != nil {
+		return result, err
+	}
+	if _, _, err := r.Client.Report.UpdateState(*report.ID, h1.ReportStateResolved, buf.String()); err != nil {
+		return result, err
+	}
+	state := h1.ReportStateResolved
+	report.State = &state
+	result.Action = ActionResolved
+	return result, nil
+}
+
+// PendingBounty returns whether a bounty has been suggested for a report which hasn't since been awarded or declined
+func PendingBounty(report *h1.Report) bool {
+	var suggested, settled time.Time
+	for _, activity := range report.Activities {
+		if activity.Type == nil || activity.CreatedAt == nil {
+			continue
+		}
+		switch *activity.Type {
+		case h1.ActivityBountySuggestedType:
+			if activity.CreatedAt.After(suggested) {
+				suggested = activity.CreatedAt.Time
+			}
+		case h1.ActivityBountyAwardedType, h1.ActivityNotEligibleForBountyType:
+			if activity.CreatedAt.After(settled) {
+				settled = activity.CreatedAt.Time
+			}
+		}
+	}
+	return !suggested.IsZero() && !settled.After(suggested)
+}
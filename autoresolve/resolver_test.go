@@ -0,0 +1,258 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package autoresolve
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/trackersync"
+
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+	"time"
+)
+
+// fakeTracker returns issues from a map
+type fakeTracker map[string]*trackersync.Issue
+
+func (f fakeTracker) Issue(key string) (*trackersync.Issue, error) {
+	issue, ok := f[key]
+	if !ok {
+		return nil, errors.New("issue does not exist")
+	}
+	return issue, nil
+}
+
+// fakeReport is a report served by newFakeServer
+type fakeReport struct {
+	ID         string
+	Reference  string
+	Activities []string // Activity types, each created a minute after the last
+}
+
+func (r fakeReport) JSON(activities bool) string {
+	attributes := `"title":"Report","state":"triaged"`
+	if r.Reference != "" {
+		attributes += fmt.Sprintf(`,"issue_tracker_reference_id":%q`, r.Reference)
+	}
+	var data []string
+	if activities {
+		created := time.Date(2016, 2, 2, 4, 5, 6, 0, time.UTC)
+		for idx, activityType := range r.Activities {
+			data = append(data, fmt.Sprintf(`{"id":"%d","type":%q,"attributes":{"created_at":%q}}`,
+				idx, activityType, created.Add(time.Duration(idx)*time.Minute).Format(time.RFC3339)))
+		}
+	}
+	return fmt.Sprintf(`{"id":%q,"type":"report","attributes":{%s},"relationships":{"activities":{"data":[%s]}}}`,
+		r.ID, attributes, strings.Join(data, ","))
+}
+
+// fakeServer serves reports and records state changes
+type fakeServer struct {
+	mu           sync.Mutex
+	reports      []fakeReport
+	stateChanges []string
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/reports" {
+		var data []string
+		for _, report := range f.reports {
+			data = append(data, report.JSON(false))
+		}
+		fmt.Fprintf(w, `{"data":[%s],"links":{}}`, strings.Join(data, ","))
+		return
+	}
+	for _, report := range f.reports {
+		switch r.URL.Path {
+		case "/reports/" + report.ID:
+			fmt.Fprintf(w, `{"data":%s}`, report.JSON(true))
+			return
+		case "/reports/" + report.ID + "/state_changes":
+			body, _ := ioutil.ReadAll(r.Body)
+			var change struct {
+				Data struct {
+					Attributes struct {
+						State   string `json:"state"`
+						Message string `json:"message"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			json.Unmarshal(body, &change)
+			f.stateChanges = append(f.stateChanges, fmt.Sprintf("%s %s: %s", report.ID, change.Data.Attributes.State, change.Data.Attributes.Message))
+			fmt.Fprint(w, `{"data":{"id":"99","type":"activity-bug-resolved","attributes":{}}}`)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func newTestResolver(t *testing.T, reports ...fakeReport) (*Resolver, *fakeServer, func()) {
+	fake := &fakeServer{reports: reports}
+	server := httptest.NewServer(fake)
+	client := h1.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	tracker := fakeTracker{
+		"SEC-1": {Key: "SEC-1", Status: "Done", Resolution: "Fixed"},
+		"SEC-2": {Key: "SEC-2", Status: "Closed", Resolution: "fixed"},
+		"SEC-3": {Key: "SEC-3", Status: "In Progress"},
+	}
+	return NewResolver(client, tracker, h1.ReportListFilter{}, time.Hour), fake, server.Close
+}
+
+func Test_Rule_Matches(t *testing.T) {
+	issue := &trackersync.Issue{Status: "Closed", Resolution: "Won't Fix"}
+	assert.False(t, (&Rule{}).Matches(issue))
+	assert.False(t, DefaultRules[0].Matches(issue))
+	assert.True(t, (&Rule{Statuses: []string{"closed"}}).Matches(issue))
+	assert.True(t, (&Rule{Resolutions: []string{"Fixed", "WON'T FIX"}}).Matches(issue))
+}
+
+func Test_PendingBounty(t *testing.T) {
+	cases := []struct {
+		activities []string
+		expected   bool
+	}{
+		{nil, false},
+		{[]string{h1.ActivityCommentType}, false},
+		{[]string{h1.ActivityBountySuggestedType}, true},
+		{[]string{h1.ActivityBountySuggestedType, h1.ActivityBountyAwardedType}, false},
+		{[]string{h1.ActivityBountySuggestedType, h1.ActivityNotEligibleForBountyType}, false},
+		{[]string{h1.ActivityBountyAwardedType, h1.ActivityBountySuggestedType}, true},
+	}
+	for _, c := range cases {
+		var report h1.Report
+		require.Nil(t, json.Unmarshal([]byte(fakeReport{ID: "1", Activities: c.activities}.JSON(true)), &report))
+		assert.Equal(t, c.expected, PendingBounty(&report), "%v", c.activities)
+	}
+}
+
+func Test_Resolver_checkAll(t *testing.T) {
+	resolver, fake, done := newTestResolver(t,
+		fakeReport{ID: "1", Reference: "SEC-1"},
+		fakeReport{ID: "2", Reference: "SEC-2", Activities: []string{h1.ActivityBountySuggestedType}},
+		fakeReport{ID: "3", Reference: "SEC-3"},
+		fakeReport{ID: "4"},
+		fakeReport{ID: "5", Reference: "SEC-404"},
+	)
+	defer done()
+
+	var results []string
+	var errs []string
+	resolver.OnResult = func(result Result) {
+		results = append(results, *result.Report.ID+" "+result.Action)
+	}
+	resolver.OnError = func(err error) {
+		errs = append(errs, err.Error())
+	}
+	resolver.checkAll(context.Background())
+
+	assert.Equal(t, []string{"1 resolved", "2 pending-bounty", "3 unchanged"}, results)
+	assert.Equal(t, []string{"issue does not exist"}, errs)
+	assert.Equal(t, []string{
+		"1 resolved: The issue tracking this report (SEC-1) has been fixed, so we're marking it as resolved. Thank you for your report!",
+	}, fake.stateChanges)
+}
+
+func Test_Resolver_Check(t *testing.T) {
+	resolver, fake, done := newTestResolver(t, fakeReport{ID: "1", Reference: "SEC-1"})
+	defer done()
+
+	resolver.Rules = []Rule{
+		{Statuses: []string{"In Progress"}},
+		{Statuses: []string{"Done"}, Message: template.Must(template.New("").Parse("Fixed in {{.Issue.Key}}: {{.Report.Title}}"))},
+	}
+	report, _, err := resolver.Client.Report.Get("1")
+	require.Nil(t, err)
+	result, err := resolver.Check(report)
+	require.Nil(t, err)
+	assert.Equal(t, ActionResolved, result.Action)
+	assert.Equal(t, &resolver.Rules[1], result.Rule)
+	assert.Equal(t, h1.ReportStateResolved, *report.State)
+	assert.Equal(t, []string{"1 resolved: Fixed in SEC-1: Report"}, fake.stateChanges)
+
+	// Failing templates don't resolve the report
+	resolver.Rules[1].Message = template.Must(template.New("").Parse("{{.Report.Reporter.Username}}"))
+	_, err = resolver.Check(report)
+	assert.Contains(t, err.Error(), "nil pointer")
+	assert.Len(t, fake.stateChanges, 1)
+
+	// Reports without a reference are unchanged
+	result, err = resolver.Check(&h1.Report{})
+	require.Nil(t, err)
+	assert.Equal(t, ActionUnchanged, result.Action)
+	assert.Nil(t, result.Issue)
+}
+
+func Test_Resolver_Run(t *testing.T) {
+	resolver, fake, done := newTestResolver(t, fakeReport{ID: "1", Reference: "SEC-1"})
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resolver.OnResult = func(result Result) {
+		cancel()
+	}
+	assert.Equal(t, context.Canceled, resolver.Run(ctx))
+	assert.Len(t, fake.stateChanges, 1)
+
+	// Verify that a resolver can only be run once
+	assert.EqualError(t, resolver.Run(context.Background()), "resolver has already been run")
+}
+
+func Test_Resolver_Stop(t *testing.T) {
+	resolver, fake, done := newTestResolver(t, fakeReport{ID: "1", Reference: "SEC-1"})
+	defer done()
+
+	checked := make(chan struct{})
+	resolver.OnResult = func(result Result) {
+		close(checked)
+	}
+	errs := make(chan error)
+	go func() {
+		errs <- resolver.Run(context.Background())
+	}()
+	<-checked
+	resolver.Stop()
+	assert.Nil(t, <-errs)
+	resolver.Stop()
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	assert.Len(t, fake.stateChanges, 1)
+}
+
+func Test_NewResolver_interval(t *testing.T) {
+	assert.Equal(t, 10*time.Minute, NewResolver(nil, nil, h1.ReportListFilter{}, 0).Interval)
+	assert.Equal(t, time.Hour, NewResolver(nil, nil, h1.ReportListFilter{}, time.Hour).Interval)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package autotriage
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+
+	"errors"
+	"fmt"
+)
+
+// Action is something done to a report when a rule matches it
+type Action interface {
+	// Apply performs the action on the report
+	Apply(client *h1.Client, report *h1.Report) error
+	// String describes the action for the audit log
+	String() string
+}
+
+// CommonResponseAction posts a common response as a comment
+type CommonResponseAction struct {
+	CommonResponse *h1.CommonResponse
+	Internal       bool // Whether the comment is only visible to the program
+}
+
+// Apply posts the common response, rendered against the report
+func (a *CommonResponseAction) Apply(client *h1.Client, report *h1.Report) error {
+	if a.CommonResponse == nil {
+		return errors.New("autotriage: common response action has no common response")
+	}
+	_, _, err := client.Report.CreateCommonResponseComment(report, a.CommonResponse, a.Internal)
+	return err
+}
+
+// String describes the common response posted by its title
+func (a *CommonResponseAction) String() string {
+	title := ""
+	if a.CommonResponse != nil && a.CommonResponse.Title != nil {
+		title = *a.CommonResponse.Title
+	}
+	if a.Internal {
+		return fmt.Sprintf("post internal common response %q", title)
+	}
+	return fmt.Sprintf("post common response %q", title)
+}
+
+// StateAction changes the state of a report
+type StateAction struct {
+	State   string
+	Message string // Posted with the state change
+}
+
+// Apply changes the state of the report
+func (a *StateAction) Apply(client *h1.Client, report *h1.Report) error {
+	_, _, err := client.Report.UpdateState(*report.ID, a.State, a.Message)
+	return err
+}
+
+// String describes the state change
+func (a *StateAction) String() string {
+	return "change state to " + a.State
+}
+
+// AssignGroupAction assigns a report to a group
+type AssignGroupAction struct {
+	GroupID string
+	Message string // Posted with the assignment
+}
+
+// Apply assigns the report to the group
+func (a *AssignGroupAction) Apply(client *h1.Client, report *h1.Report) error {
+	_, _, err := client.Report.UpdateAssignee(*report.ID, h1.GroupType, a.GroupID, a.Message)
+	return err
+}
+
+// String describes the assignment
+func (a *AssignGroupAction) String() string {
+	return "assign to group " + a.GroupID
+}
+
+// SeverityAction sets the severity rating of a report
+type SeverityAction struct {
+	Rating string
+}
+
+// Apply sets the severity rating of the report
+func (a *SeverityAction) Apply(client *h1.Client, report *h1.Report) error {
+	_, _, err := client.Report.UpdateSeverity(*report.ID, a.Rating)
+	return err
+}
+
+// String describes the severity change
+func (a *SeverityAction) String() string {
+	return "set severity to " + a.Rating
+}
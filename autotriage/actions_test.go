@@ -0,0 +1,117 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package autotriage
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// fakeServer records the requests made to it, failing those to failPath
+type fakeServer struct {
+	mu       sync.Mutex
+	requests []string
+	failPath string
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+	f.requests = append(f.requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+	if r.URL.Path == f.failPath {
+		http.Error(w, "Oh No", 500)
+		return
+	}
+	fmt.Fprint(w, `{"data":{"id":"1","type":"activity-comment","attributes":{}}}`)
+}
+
+func newTestClient(t *testing.T) (*h1.Client, *fakeServer, func()) {
+	fake := &fakeServer{}
+	server := httptest.NewServer(fake)
+	client := h1.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return client, fake, server.Close
+}
+
+func Test_Actions(t *testing.T) {
+	client, fake, done := newTestClient(t)
+	defer done()
+
+	report := &h1.Report{ID: h1.String("1337"), Title: h1.String("XSS")}
+	cases := []struct {
+		action      Action
+		description string
+		request     string
+	}{
+		{
+			&CommonResponseAction{CommonResponse: &h1.CommonResponse{Title: h1.String("Spam"), Message: h1.String("Closing {{.ReportTitle}}")}},
+			`post common response "Spam"`,
+			`POST /reports/1337/activities {"data":{"type":"activity-comment","attributes":{"message":"Closing XSS","internal":false}}}`,
+		},
+		{
+			&CommonResponseAction{CommonResponse: &h1.CommonResponse{Message: h1.String("Hmm")}, Internal: true},
+			`post internal common response ""`,
+			`POST /reports/1337/activities {"data":{"type":"activity-comment","attributes":{"message":"Hmm","internal":true}}}`,
+		},
+		{
+			&StateAction{State: h1.ReportStateSpam, Message: "Spam"},
+			"change state to spam",
+			`POST /reports/1337/state_changes {"data":{"type":"state-change","attributes":{"state":"spam","message":"Spam"}}}`,
+		},
+		{
+			&AssignGroupAction{GroupID: "42"},
+			"assign to group 42",
+			`PUT /reports/1337/assignee {"data":{"id":"42","type":"group","attributes":{}}}`,
+		},
+		{
+			&SeverityAction{Rating: h1.SeverityRatingLow},
+			"set severity to low",
+			`POST /reports/1337/severities {"data":{"type":"severity","attributes":{"rating":"low"}}}`,
+		},
+	}
+	for _, c := range cases {
+		fake.requests = nil
+		require.Nil(t, c.action.Apply(client, report), c.description)
+		assert.Equal(t, c.description, c.action.String())
+		assert.Equal(t, []string{c.request + "\n"}, fake.requests)
+	}
+
+	fake.failPath = "/reports/1337/severities"
+	assert.NotNil(t, (&SeverityAction{Rating: h1.SeverityRatingLow}).Apply(client, report))
+
+	// Verify that a missing common response fails rather than panicking
+	fake.requests = nil
+	action := &CommonResponseAction{}
+	assert.Equal(t, `post common response ""`, action.String())
+	assert.EqualError(t, action.Apply(client, report), "autotriage: common response action has no common response")
+	assert.Empty(t, fake.requests)
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package autotriage applies actions to new reports which match rules, such as closing obvious spam or routing reports about an asset to the team which owns it.
+package autotriage
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/polling"
+
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rule matches reports and lists the actions to take on them. Every condition which is set must match, and a rule without any conditions never matches.
+type Rule struct {
+	Name                     string
+	Title                    *regexp.Regexp // Matches the report's title
+	VulnerabilityInformation *regexp.Regexp // Matches the report's vulnerability information
+	MinSignal                *float64       // The reporter's signal must be at least this
+	MaxSignal                *float64       // The reporter's signal must be at most this
+	MinReputation            *uint64        // The reporter's reputation must be at least this
+	MaxReputation            *uint64        // The reporter's reputation must be at most this
+	VulnerabilityTypes       []string       // The report must have one of these vulnerability types, compared case-insensitively
+	Assets                   []string       // The report's structured scope must have one of these asset identifiers, compared case-insensitively
+	Actions                  []Action
+}
+
+// Matches returns whether a report matches every condition of the rule. Reporter conditions don't match when the reporter's signal or reputation is unknown.
+func (r *Rule) Matches(report *h1.Report) bool {
+	conditions := 0
+	check := func(set bool, matches func() bool) bool {
+		if !set {
+			return true
+		}
+		conditions++
+		return matches()
+	}
+
+	var signal *float64
+	var reputation *uint64
+	if report.Reporter != nil {
+		signal = report.Reporter.Signal
+		reputation = report.Reporter.Reputation
+	}
+
+	matches := check(r.Title != nil, func() bool {
+		return report.Title != nil && r.Title.MatchString(*report.Title)
+	}) && check(r.VulnerabilityInformation != nil, func() bool {
+		return report.VulnerabilityInformation != nil && r.VulnerabilityInformation.MatchString(*report.VulnerabilityInformation)
+	}) && check(r.MinSignal != nil, func() bool {
+		return signal != nil && *signal >= *r.MinSignal
+	}) && check(r.MaxSignal != nil, func() bool {
+		return signal != nil && *signal <= *r.MaxSignal
+	}) && check(r.MinReputation != nil, func() bool {
+		return reputation != nil && *reputation >= *r.MinReputation
+	}) && check(r.MaxReputation != nil, func() bool {
+		return reputation != nil && *reputation <= *r.MaxReputation
+	}) && check(len(r.VulnerabilityTypes) > 0, func() bool {
+		for _, vulnerabilityType := range report.VulnerabilityTypes {
+			if vulnerabilityType.Name != nil && containsFold(r.VulnerabilityTypes, *vulnerabilityType.Name) {
+				return true
+			}
+		}
+		return false
+	}) && check(len(r.Assets) > 0, func() bool {
+		return report.StructuredScope != nil && report.StructuredScope.AssetIdentifier != nil &&
+			containsFold(r.Assets, *report.StructuredScope.AssetIdentifier)
+	})
+	return matches && conditions > 0
+}
+
+// containsFold returns whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditEntry records an action taken, or which would have been taken in a dry run
+type AuditEntry struct {
+	Time     time.Time `json:"time"`
+	ReportID string    `json:"report_id"`
+	Rule     string    `json:"rule"`
+	Action   string    `json:"action"`
+	DryRun   bool      `json:"dry_run"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Triager applies the actions of the first rule matching each new report
+type Triager struct {
+	Client  *h1.Client
+	Rules   []Rule
+	DryRun  bool            // Only record the actions which would be taken in the audit log
+	Audit   io.Writer       // Receives each AuditEntry as a line of JSON, entries are only returned if nil
+	OnError func(err error) // Called when Dispatch fails to triage a report, errors are dropped if nil
+
+	mu  sync.Mutex       // Serializes writes to Audit
+	now func() time.Time // Used to timestamp audit entries, defaults to time.Now
+}
+
+// NewTriager creates a Triager
+func NewTriager(client *h1.Client, rules []Rule, dryRun bool, audit io.Writer) *Triager {
+	return &Triager{
+		Client: client,
+		Rules:  rules,
+		DryRun: dryRun,
+		Audit:  audit,
+	}
+}
+
+// Dispatch triages the report of each EventReportCreated, so a Triager can be used with polling.Handlers or be passed the poller's events directly
+func (t *Triager) Dispatch(event polling.Event) {
+	if event.Type != polling.EventReportCreated {
+		return
+	}
+	if _, err := t.Triage(event.Report); err != nil && t.OnError != nil {
+		t.OnError(err)
+	}
+}
+
+// Triage applies the actions of the first rule matching the report, stopping at the first action which fails. It returns an AuditEntry for each action attempted.
+func (t *Triager) Triage(report *h1.Report) ([]AuditEntry, error) {
+	var rule *Rule
+	for idx := range t.Rules {
+		if t.Rules[idx].Matches(report) {
+			rule = &t.Rules[idx]
+			break
+		}
+	}
+	if rule == nil {
+		return nil, nil
+	}
+
+	now := t.now
+	if now == nil {
+		now = time.Now
+	}
+	var entries []AuditEntry
+	for _, action := range rule.Actions {
+		entry := AuditEntry{
+			Time:     now().UTC(),
+			ReportID: *report.ID,
+			Rule:     rule.Name,
+			Action:   action.String(),
+			DryRun:   t.DryRun,
+		}
+		var err error
+		if !t.DryRun {
+			err = action.Apply(t.Client, report)
+			if err != nil {
+				entry.Error = err.Error()
+			}
+		}
+		entries = append(entries, entry)
+		if auditErr := t.audit(entry); auditErr != nil && err == nil {
+			err = auditErr
+		}
+		if err != nil {
+			return entries, err
+		}
+	}
+	return entries, nil
+}
+
+// audit writes an entry to the audit log
+func (t *Triager) audit(entry AuditEntry) error {
+	if t.Audit == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return json.NewEncoder(t.Audit).Encode(entry)
+}
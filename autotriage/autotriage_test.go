@@ -0,0 +1,184 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package autotriage
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/polling"
+
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestReport() *h1.Report {
+	return &h1.Report{
+		ID:                       h1.String("1337"),
+		Title:                    h1.String("XSS in login form"),
+		VulnerabilityInformation: h1.String("Steps: visit https://example.com/?q=<script>"),
+		Reporter: &h1.User{
+			Username:   h1.String("hacker"),
+			Signal:     h1.Float64(-2.5),
+			Reputation: h1.Uint64(10),
+		},
+		VulnerabilityTypes: []h1.VulnerabilityType{
+			{Name: h1.String("Cross-Site Scripting (XSS)")},
+		},
+		StructuredScope: &h1.StructuredScope{AssetIdentifier: h1.String("www.example.com")},
+	}
+}
+
+func Test_Rule_Matches(t *testing.T) {
+	cases := []struct {
+		rule     Rule
+		expected bool
+	}{
+		{Rule{}, false},
+		{Rule{Title: regexp.MustCompile(`(?i)xss`)}, true},
+		{Rule{Title: regexp.MustCompile(`(?i)csrf`)}, false},
+		{Rule{VulnerabilityInformation: regexp.MustCompile(`<script>`)}, true},
+		{Rule{MaxSignal: h1.Float64(0)}, true},
+		{Rule{MinSignal: h1.Float64(0)}, false},
+		{Rule{MinReputation: h1.Uint64(10), MaxReputation: h1.Uint64(10)}, true},
+		{Rule{MaxReputation: h1.Uint64(5)}, false},
+		{Rule{VulnerabilityTypes: []string{"SQL Injection", "cross-site scripting (xss)"}}, true},
+		{Rule{VulnerabilityTypes: []string{"SQL Injection"}}, false},
+		{Rule{Assets: []string{"WWW.EXAMPLE.COM"}}, true},
+		{Rule{Assets: []string{"api.example.com"}}, false},
+		{Rule{Title: regexp.MustCompile(`XSS`), Assets: []string{"api.example.com"}}, false},
+		{Rule{Title: regexp.MustCompile(`XSS`), MaxSignal: h1.Float64(0), Assets: []string{"www.example.com"}}, true},
+	}
+	for idx, c := range cases {
+		assert.Equal(t, c.expected, c.rule.Matches(newTestReport()), "case %d", idx)
+	}
+
+	// Unknown reporter attributes never match
+	report := newTestReport()
+	report.Reporter = nil
+	report.Title = nil
+	report.StructuredScope = nil
+	assert.False(t, (&Rule{MaxSignal: h1.Float64(100)}).Matches(report))
+	assert.False(t, (&Rule{MaxReputation: h1.Uint64(100)}).Matches(report))
+	assert.False(t, (&Rule{Title: regexp.MustCompile(``)}).Matches(report))
+	assert.False(t, (&Rule{Assets: []string{"www.example.com"}}).Matches(report))
+}
+
+func newTestTriager(client *h1.Client, dryRun bool, audit io.Writer) *Triager {
+	triager := NewTriager(client, []Rule{
+		{
+			Name:    "low-signal-xss",
+			Title:   regexp.MustCompile(`(?i)xss`),
+			Assets:  []string{"api.example.com"},
+			Actions: []Action{&SeverityAction{Rating: h1.SeverityRatingHigh}},
+		},
+		{
+			Name:      "low-signal",
+			MaxSignal: h1.Float64(0),
+			Actions: []Action{
+				&AssignGroupAction{GroupID: "42"},
+				&SeverityAction{Rating: h1.SeverityRatingLow},
+			},
+		},
+		{
+			Name:    "everything",
+			Title:   regexp.MustCompile(``),
+			Actions: []Action{&StateAction{State: h1.ReportStateNeedsMoreInfo}},
+		},
+	}, dryRun, audit)
+	triager.now = func() time.Time {
+		return time.Date(2016, 2, 2, 4, 5, 6, 0, time.UTC)
+	}
+	return triager
+}
+
+func Test_Triager_Triage(t *testing.T) {
+	client, fake, done := newTestClient(t)
+	defer done()
+
+	// Dry runs only audit
+	var audit bytes.Buffer
+	triager := newTestTriager(client, true, &audit)
+	entries, err := triager.Triage(newTestReport())
+	require.Nil(t, err)
+	assert.Equal(t, []AuditEntry{
+		{Time: time.Date(2016, 2, 2, 4, 5, 6, 0, time.UTC), ReportID: "1337", Rule: "low-signal", Action: "assign to group 42", DryRun: true},
+		{Time: time.Date(2016, 2, 2, 4, 5, 6, 0, time.UTC), ReportID: "1337", Rule: "low-signal", Action: "set severity to low", DryRun: true},
+	}, entries)
+	assert.Empty(t, fake.requests)
+	assert.Equal(t, strings.Join([]string{
+		`{"time":"2016-02-02T04:05:06Z","report_id":"1337","rule":"low-signal","action":"assign to group 42","dry_run":true}`,
+		`{"time":"2016-02-02T04:05:06Z","report_id":"1337","rule":"low-signal","action":"set severity to low","dry_run":true}`,
+	}, "\n")+"\n", audit.String())
+
+	// Otherwise actions are applied in order, stopping at the first failure
+	audit.Reset()
+	triager = newTestTriager(client, false, &audit)
+	fake.failPath = "/reports/1337/severities"
+	entries, err = triager.Triage(newTestReport())
+	assert.NotNil(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "", entries[0].Error)
+	assert.Equal(t, err.Error(), entries[1].Error)
+	assert.Len(t, fake.requests, 2)
+	assert.Equal(t, 2, strings.Count(audit.String(), "\n"))
+
+	// Only the first matching rule is applied
+	fake.requests = nil
+	report := newTestReport()
+	report.Reporter = nil
+	entries, err = triager.Triage(report)
+	require.Nil(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "everything", entries[0].Rule)
+	assert.Len(t, fake.requests, 1)
+
+	// Reports matching no rule are left alone
+	triager.Rules = nil
+	entries, err = triager.Triage(report)
+	assert.Nil(t, err)
+	assert.Nil(t, entries)
+}
+
+func Test_Triager_Dispatch(t *testing.T) {
+	client, fake, done := newTestClient(t)
+	defer done()
+	fake.failPath = "/reports/1337/assignee"
+
+	var errs []error
+	triager := newTestTriager(client, false, nil)
+	triager.OnError = func(err error) {
+		errs = append(errs, err)
+	}
+
+	var handlers polling.Handlers
+	handlers.On(polling.EventReportCreated, triager.Dispatch)
+	handlers.Dispatch(polling.Event{Type: polling.EventStateChanged, Report: newTestReport()})
+	assert.Empty(t, fake.requests)
+
+	triager.Dispatch(polling.Event{Type: polling.EventReportCreated, Report: newTestReport()})
+	assert.Len(t, fake.requests, 1)
+	assert.Len(t, errs, 1)
+}
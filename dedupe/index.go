@@ -0,0 +1,235 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package dedupe finds reports which are likely duplicates of earlier ones by comparing their content.
+package dedupe
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+
+	"math"
+	"sort"
+	"sync"
+)
+
+// TitleWeight is how many times more a token in a report's title counts than one in its vulnerability information
+const TitleWeight = 3
+
+// Candidate is an indexed report which may be the original of a duplicate
+type Candidate struct {
+	ReportID string
+	Title    string
+	State    string
+	Score    float64 // The cosine similarity of the reports' TF-IDF weighted tokens, from 0 to 1
+}
+
+// document is an indexed report
+type document struct {
+	title string
+	state string
+	terms map[string]int // How many times each token occurs, with title tokens counted TitleWeight times
+	order []string       // The tokens sorted, so scores are summed in the same order every time
+}
+
+// Index holds the tokens of reports so similar reports can be found. It's safe for concurrent use.
+type Index struct {
+	MinScore float64 // Candidates scoring lower are omitted
+
+	mu       sync.RWMutex
+	docs     map[string]*document
+	postings map[string]map[string]int // The documents containing each token and how often
+}
+
+// NewIndex creates an empty Index
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[string]*document),
+		postings: make(map[string]map[string]int),
+	}
+}
+
+// Build creates an Index of every report matching the filter
+func Build(client *h1.Client, filter h1.ReportListFilter) (*Index, error) {
+	index := NewIndex()
+	var listOptions h1.ListOptions
+	for {
+		reports, resp, err := client.Report.List(filter, &listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for idx := range reports {
+			index.Add(&reports[idx])
+		}
+		if resp.Links.Next == "" {
+			break
+		}
+		listOptions.Page = resp.Links.NextPageNumber()
+	}
+	return index, nil
+}
+
+// newTerms returns how many times each token occurs in a report
+func newTerms(report *h1.Report) map[string]int {
+	terms := make(map[string]int)
+	if report.Title != nil {
+		for _, token := range Tokenize(*report.Title) {
+			terms[token] += TitleWeight
+		}
+	}
+	if report.VulnerabilityInformation != nil {
+		for _, token := range Tokenize(*report.VulnerabilityInformation) {
+			terms[token]++
+		}
+	}
+	return terms
+}
+
+// sortedTerms returns the tokens of terms in order. Floating point addition isn't associative, so summing in map order would make scores vary between calls
+func sortedTerms(terms map[string]int) []string {
+	order := make([]string, 0, len(terms))
+	for term := range terms {
+		order = append(order, term)
+	}
+	sort.Strings(order)
+	return order
+}
+
+// Add indexes a report, replacing it if it was already indexed
+func (i *Index) Add(report *h1.Report) {
+	doc := &document{terms: newTerms(report)}
+	doc.order = sortedTerms(doc.terms)
+	if report.Title != nil {
+		doc.title = *report.Title
+	}
+	if report.State != nil {
+		doc.state = *report.State
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(*report.ID)
+	i.docs[*report.ID] = doc
+	for term, count := range doc.terms {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[string]int)
+		}
+		i.postings[term][*report.ID] = count
+	}
+}
+
+// Remove removes a report from the index
+func (i *Index) Remove(ID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(ID)
+}
+
+// remove removes a report from the index, the caller must hold the lock
+func (i *Index) remove(ID string) {
+	doc, ok := i.docs[ID]
+	if !ok {
+		return
+	}
+	delete(i.docs, ID)
+	for term := range doc.terms {
+		delete(i.postings[term], ID)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+}
+
+// Len returns the number of reports indexed
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.docs)
+}
+
+// idf returns the inverse document frequency of a token, the caller must hold the lock
+func (i *Index) idf(term string) float64 {
+	return math.Log(1 + float64(len(i.docs))/float64(1+len(i.postings[term])))
+}
+
+// Candidates returns up to limit indexed reports sharing tokens with the report, most similar first. The report itself is never a candidate, and a limit of zero or less returns every candidate.
+func (i *Index) Candidates(report *h1.Report, limit int) []Candidate {
+	terms := newTerms(report)
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	// Weight the report's tokens and accumulate the dot product with each document sharing them
+	idfs := make(map[string]float64)
+	weight := func(term string, count int) float64 {
+		idf, ok := idfs[term]
+		if !ok {
+			idf = i.idf(term)
+			idfs[term] = idf
+		}
+		return float64(count) * idf
+	}
+	var norm float64
+	dots := make(map[string]float64)
+	for _, term := range sortedTerms(terms) {
+		w := weight(term, terms[term])
+		norm += w * w
+		for ID, docCount := range i.postings[term] {
+			dots[ID] += w * weight(term, docCount)
+		}
+	}
+	if norm == 0 {
+		return nil
+	}
+	norm = math.Sqrt(norm)
+
+	var candidates []Candidate
+	for ID, dot := range dots {
+		if report.ID != nil && ID == *report.ID {
+			continue
+		}
+		doc := i.docs[ID]
+		var docNorm float64
+		for _, term := range doc.order {
+			w := weight(term, doc.terms[term])
+			docNorm += w * w
+		}
+		score := dot / (norm * math.Sqrt(docNorm))
+		if score < i.MinScore || score == 0 {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			ReportID: ID,
+			Title:    doc.title,
+			State:    doc.state,
+			Score:    score,
+		})
+	}
+
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].Score != candidates[b].Score {
+			return candidates[a].Score > candidates[b].Score
+		}
+		return candidates[a].ReportID < candidates[b].ReportID
+	})
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
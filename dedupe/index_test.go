@@ -0,0 +1,156 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dedupe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+func newTestReport(ID string, title string, info string) *h1.Report {
+	return &h1.Report{
+		ID:                       h1.String(ID),
+		Title:                    h1.String(title),
+		VulnerabilityInformation: h1.String(info),
+		State:                    h1.String(h1.ReportStateTriaged),
+	}
+}
+
+func newTestIndex() *Index {
+	index := NewIndex()
+	index.Add(newTestReport("1", "Reflected XSS in search", "Visit https://example.com/search?q=<script>alert(1)</script>"))
+	index.Add(newTestReport("2", "Open redirect on login", "https://example.com/login?next=https://evil.com redirects to evil.com"))
+	index.Add(newTestReport("3", "CSRF on account settings", "The settings form at https://example.com/account/settings has no token"))
+	index.Add(newTestReport("4", "Stored XSS in profile", "The bio at https://example.com/users/7/profile isn't escaped"))
+	return index
+}
+
+func Test_Index_Candidates(t *testing.T) {
+	index := newTestIndex()
+	assert.Equal(t, 4, index.Len())
+
+	// A rewording of the first report with a different payload ranks it first
+	report := newTestReport("5", "XSS in the search page", "https://www.example.com/search/?q=%22onmouseover=alert(2)")
+	candidates := index.Candidates(report, 0)
+	require.True(t, len(candidates) >= 2)
+	assert.Equal(t, "1", candidates[0].ReportID)
+	assert.Equal(t, "Reflected XSS in search", candidates[0].Title)
+	assert.Equal(t, h1.ReportStateTriaged, candidates[0].State)
+	assert.Equal(t, "4", candidates[1].ReportID)
+	for idx, candidate := range candidates {
+		assert.True(t, candidate.Score > 0 && candidate.Score <= 1, "%v", candidate)
+		if idx > 0 {
+			assert.True(t, candidates[idx-1].Score >= candidate.Score)
+		}
+	}
+
+	// Limits and minimum scores are applied
+	assert.Len(t, index.Candidates(report, 1), 1)
+	index.MinScore = candidates[0].Score - 1e-9
+	assert.Len(t, index.Candidates(report, 0), 1)
+	index.MinScore = 0
+
+	// Identical reports score one and the report itself is never a candidate
+	same := newTestReport("6", "Open redirect on login", "https://example.com/login?next=https://evil.com redirects to evil.com")
+	candidates = index.Candidates(same, 1)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "2", candidates[0].ReportID)
+	assert.InDelta(t, 1, candidates[0].Score, 1e-9)
+	same.ID = h1.String("2")
+	for _, candidate := range index.Candidates(same, 0) {
+		assert.NotEqual(t, "2", candidate.ReportID)
+	}
+
+	// Reports without tokens have no candidates
+	assert.Nil(t, index.Candidates(newTestReport("7", "", "the a of"), 0))
+}
+
+func Test_Index_AddRemove(t *testing.T) {
+	index := newTestIndex()
+	report := newTestReport("5", "CSRF on settings", "")
+	assert.Equal(t, "3", index.Candidates(report, 1)[0].ReportID)
+
+	// Re-adding a report replaces its tokens
+	index.Add(newTestReport("3", "SQL injection", "id=1' OR 1=1"))
+	assert.Equal(t, 4, index.Len())
+	for _, candidate := range index.Candidates(report, 0) {
+		assert.NotEqual(t, "3", candidate.ReportID)
+	}
+
+	index.Remove("3")
+	index.Remove("404")
+	assert.Equal(t, 3, index.Len())
+	assert.Empty(t, index.Candidates(newTestReport("5", "SQL injection", ""), 0))
+	assert.Empty(t, index.postings["sql"])
+}
+
+func Test_Index_Concurrency(t *testing.T) {
+	index := newTestIndex()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			report := newTestReport(fmt.Sprint(10+i), "XSS in search", "")
+			index.Candidates(report, 0)
+			index.Add(report)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 14, index.Len())
+}
+
+func Test_Build(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/reports" {
+			http.Error(w, "Oh No", 500)
+			return
+		}
+		switch r.URL.Query().Get("page[number]") {
+		case "":
+			fmt.Fprintf(w, `{"data":[{"id":"1","type":"report","attributes":{"title":"XSS in search","state":"resolved"}}],"links":{"next":"%s/reports?page%%5Bnumber%%5D=2"}}`, "http://"+r.Host)
+		case "2":
+			fmt.Fprint(w, `{"data":[{"id":"2","type":"report","attributes":{"title":"Open redirect","state":"new"}}],"links":{}}`)
+		}
+	}))
+	defer server.Close()
+
+	client := h1.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	index, err := Build(client, h1.ReportListFilter{Program: []string{"example"}})
+	require.Nil(t, err)
+	assert.Equal(t, 2, index.Len())
+	candidates := index.Candidates(newTestReport("3", "XSS", ""), 0)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, Candidate{ReportID: "1", Title: "XSS in search", State: h1.ReportStateResolved, Score: candidates[0].Score}, candidates[0])
+
+	client.BaseURL, _ = url.Parse(server.URL + "/error/")
+	_, err = Build(client, h1.ReportListFilter{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dedupe
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// urlPattern matches URLs in free text
+var urlPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s"'<>]+`)
+
+// idPattern matches path segments which are likely identifiers, such as numbers, UUIDs and hashes
+var idPattern = regexp.MustCompile(`^(?:[0-9]+|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|[0-9a-f]{16,})$`)
+
+// stopWords are too common in reports to tell them apart
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"can": true, "for": true, "from": true, "has": true, "have": true, "in": true, "is": true, "it": true,
+	"of": true, "on": true, "or": true, "that": true, "the": true, "this": true, "to": true, "was": true,
+	"which": true, "with": true,
+}
+
+// Tokenize splits text into lowercase words, without stop words, and URL tokens. Each URL becomes a "host:" token, a "path:" token with identifiers replaced by "{id}", and a "param:" token for each query parameter name, so URLs differing only by identifiers or parameter values are the same.
+func Tokenize(text string) []string {
+	var tokens []string
+	text = urlPattern.ReplaceAllStringFunc(text, func(raw string) string {
+		tokens = append(tokens, urlTokens(strings.TrimRight(raw, ".,;:!?)]}"))...)
+		return " "
+	})
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '_'
+	})
+	for _, word := range words {
+		if len(word) < 2 || stopWords[word] {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// urlTokens returns the normalized tokens for a URL
+func urlTokens(raw string) []string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	tokens := []string{"host:" + host}
+
+	var segments []string
+	for _, segment := range strings.Split(strings.ToLower(u.Path), "/") {
+		if segment == "" {
+			continue
+		}
+		if idPattern.MatchString(segment) {
+			segment = "{id}"
+		}
+		segments = append(segments, segment)
+	}
+	if len(segments) > 0 {
+		tokens = append(tokens, "path:"+host+"/"+strings.Join(segments, "/"))
+	}
+
+	var params []string
+	for name := range u.Query() {
+		params = append(params, "param:"+strings.ToLower(name))
+	}
+	sort.Strings(params)
+	return append(tokens, params...)
+}
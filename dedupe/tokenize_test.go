@@ -0,0 +1,49 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dedupe
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"testing"
+)
+
+func Test_Tokenize(t *testing.T) {
+	assert.Equal(t, []string{"xss", "login", "form"}, Tokenize("XSS in the login form!"))
+	assert.Equal(t, []string{"open_redirect", "über", "42"}, Tokenize("open_redirect, über a 42"))
+	assert.Empty(t, Tokenize(""))
+
+	// URLs are normalized and removed from the words
+	assert.Equal(t, []string{
+		"host:example.com", "path:example.com/users/{id}/profile", "param:next", "param:q",
+		"visit", "then",
+	}, Tokenize("Visit https://www.Example.com/users/1337/Profile/?q=%3Cscript%3E&next=/home, then"))
+	assert.Equal(t, []string{
+		"host:example.com", "path:example.com/files/{id}",
+		"host:api.example.com",
+	}, Tokenize("(http://example.com/files/0f8fad5b-d9cb-469f-a165-70867728950e) https://api.example.com"))
+
+	// Identical URLs besides identifiers and parameter values have the same tokens
+	assert.Equal(t,
+		Tokenize("https://example.com/reports/1/edit?id=1&token=abc"),
+		Tokenize("http://www.example.com/reports/deadbeefdeadbeefdeadbeef/edit/?token=xyz&id=2."),
+	)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// AnalyticsKey and AnalyticsInterval represent possible metrics and intervals that can be queried
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#analytics
+const (
+	AnalyticsKeySubmittedReports        string = "submitted-reports"
+	AnalyticsKeyResolvedReports         string = "resolved-reports"
+	AnalyticsKeyBountiesPaid            string = "bounties-paid"
+	AnalyticsKeyMeanTimeToFirstResponse string = "mean-time-to-first-response"
+	AnalyticsKeyMeanTimeToTriage        string = "mean-time-to-triage"
+	AnalyticsKeyMeanTimeToBounty        string = "mean-time-to-bounty"
+	AnalyticsKeyMeanTimeToResolution    string = "mean-time-to-resolution"
+	AnalyticsIntervalDay                string = "day"
+	AnalyticsIntervalWeek               string = "week"
+	AnalyticsIntervalMonth              string = "month"
+	AnalyticsIntervalQuarter            string = "quarter"
+	AnalyticsIntervalYear               string = "year"
+)
+
+// The column of a query result holding the start of each interval
+const analyticsIntervalColumn = "interval"
+
+// AnalyticsDataPoint represents the values of a query for a single interval.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#analytics
+type AnalyticsDataPoint struct {
+	Interval *Timestamp         // The start of the interval
+	Values   map[string]float64 // The values by column, columns without a value for the interval are omitted
+}
+
+// AnalyticsTimeSeries represents the result of an analytics query, ordered by interval.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#analytics
+type AnalyticsTimeSeries []AnalyticsDataPoint
+
+// Helper types for JSONUnmarshal
+type analyticsTimeSeriesUnmarshalHelper struct {
+	Keys   []string            `json:"keys"`
+	Values [][]json.RawMessage `json:"values"`
+}
+
+// UnmarshalJSON converts the tabular keys and values returned by the API into data points.
+func (a *AnalyticsTimeSeries) UnmarshalJSON(b []byte) error {
+	var helper analyticsTimeSeriesUnmarshalHelper
+	if err := json.Unmarshal(b, &helper); err != nil {
+		return err
+	}
+	series := make(AnalyticsTimeSeries, 0, len(helper.Values))
+	for _, row := range helper.Values {
+		if len(row) != len(helper.Keys) {
+			return fmt.Errorf("analytics row has %d values but %d keys", len(row), len(helper.Keys))
+		}
+		point := AnalyticsDataPoint{
+			Values: make(map[string]float64),
+		}
+		for idx, key := range helper.Keys {
+			raw := row[idx]
+			if string(raw) == "null" {
+				continue
+			}
+			if key == analyticsIntervalColumn {
+				point.Interval = new(Timestamp)
+				if err := json.Unmarshal(raw, point.Interval); err != nil {
+					return err
+				}
+				continue
+			}
+			value, err := parseAnalyticsValue(raw)
+			if err != nil {
+				return err
+			}
+			point.Values[key] = value
+		}
+		series = append(series, point)
+	}
+	*a = series
+	return nil
+}
+
+// parseAnalyticsValue accepts both JSON numbers and decimal strings
+func parseAnalyticsValue(raw json.RawMessage) (float64, error) {
+	var value float64
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, nil
+	}
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(str, 64)
+}
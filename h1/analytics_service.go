@@ -0,0 +1,60 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"time"
+)
+
+// AnalyticsService handles communication with the analytics related methods of the H1 API.
+type AnalyticsService service
+
+// AnalyticsQuery specifies the parameters to the AnalyticsService.Query method.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#analytics
+type AnalyticsQuery struct {
+	Key       string    `url:"key"`
+	Interval  string    `url:"interval"`
+	StartAt   time.Time `url:"start_at"`
+	EndAt     time.Time `url:"end_at"`
+	ProgramID []string  `url:"team_id,brackets,omitempty"`
+}
+
+// Query runs an analytics query and returns the resulting time series
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#analytics
+func (s *AnalyticsService) Query(query AnalyticsQuery) (AnalyticsTimeSeries, *Response, error) {
+	// addOptions takes structs only so it can't fail
+	u, _ := addOptions("analytics", &query, nil)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	series := new(AnalyticsTimeSeries)
+	resp, err := s.client.Do(req, series)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *series, resp, err
+}
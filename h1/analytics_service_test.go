@@ -0,0 +1,78 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func Test_AnalyticsService_Query(t *testing.T) {
+	query := AnalyticsQuery{
+		Key:       AnalyticsKeyMeanTimeToFirstResponse,
+		Interval:  AnalyticsIntervalMonth,
+		StartAt:   NewTimestamp("2016-01-01T00:00:00Z").Time,
+		EndAt:     NewTimestamp("2016-03-01T00:00:00Z").Time,
+		ProgramID: []string{"1337"},
+	}
+
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{
+		Scheme: "http://[fe80::1%en0]/",
+	}
+	_, _, err := c.Analytics.Query(query)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Analytics.Query(query)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	analyticsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/analytics", r.URL.Path)
+		values := r.URL.Query()
+		assert.Equal(t, AnalyticsKeyMeanTimeToFirstResponse, values.Get("key"))
+		assert.Equal(t, AnalyticsIntervalMonth, values.Get("interval"))
+		assert.Equal(t, "2016-01-01T00:00:00Z", values.Get("start_at"))
+		assert.Equal(t, "2016-03-01T00:00:00Z", values.Get("end_at"))
+		assert.Equal(t, "1337", values.Get("team_id[]"))
+		http.ServeFile(w, r, "tests/responses/analytics.json")
+	}))
+	defer analyticsServer.Close()
+	u, err = url.Parse(analyticsServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Analytics.Query(query)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAnalyticsTimeSeries, actual)
+}
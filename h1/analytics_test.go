@@ -0,0 +1,77 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+var expectedAnalyticsTimeSeries = AnalyticsTimeSeries{
+	AnalyticsDataPoint{
+		Interval: NewTimestamp("2016-01-01T00:00:00.000Z"),
+		Values: map[string]float64{
+			AnalyticsKeyMeanTimeToFirstResponse: 3600.5,
+			AnalyticsKeySubmittedReports:        12,
+		},
+	},
+	AnalyticsDataPoint{
+		Interval: NewTimestamp("2016-02-01T00:00:00.000Z"),
+		Values: map[string]float64{
+			AnalyticsKeySubmittedReports: 0,
+		},
+	},
+}
+
+func Test_AnalyticsTimeSeries(t *testing.T) {
+	testJSON, err := ioutil.ReadFile("tests/resources/analytics.json")
+	require.Nil(t, err)
+	var actual AnalyticsTimeSeries
+	err = json.Unmarshal(testJSON, &actual)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAnalyticsTimeSeries, actual)
+}
+
+func Test_AnalyticsTimeSeries_Invalid(t *testing.T) {
+	var actual AnalyticsTimeSeries
+
+	// Verify that an invalid document fails
+	err := json.Unmarshal([]byte(`{"keys":123}`), &actual)
+	assert.NotNil(t, err)
+
+	// Verify that rows must match the keys
+	err = json.Unmarshal([]byte(`{"keys":["interval","bounties-paid"],"values":[["2016-01-01T00:00:00.000Z"]]}`), &actual)
+	assert.NotNil(t, err)
+
+	// Verify that an invalid interval fails
+	err = json.Unmarshal([]byte(`{"keys":["interval"],"values":[["January"]]}`), &actual)
+	assert.NotNil(t, err)
+
+	// Verify that invalid values fail
+	err = json.Unmarshal([]byte(`{"keys":["bounties-paid"],"values":[["lots"]]}`), &actual)
+	assert.NotNil(t, err)
+	err = json.Unmarshal([]byte(`{"keys":["bounties-paid"],"values":[[true]]}`), &actual)
+	assert.NotNil(t, err)
+}
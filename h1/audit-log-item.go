@@ -0,0 +1,66 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"encoding/json"
+)
+
+// AuditLogItem represents a single entry of a program's audit log.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#audit-log-item
+type AuditLogItem struct {
+	ID         *string                `json:"id"`
+	Type       *string                `json:"type"`
+	Log        *string                `json:"log"`
+	Event      *string                `json:"event"`
+	Source     *string                `json:"source"`
+	Subject    *string                `json:"subject,omitempty"`
+	UserAgent  *string                `json:"user_agent,omitempty"`
+	Country    *string                `json:"country,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	CreatedAt  *Timestamp             `json:"created_at"`
+	Actor      *User                  `json:"actor,omitempty"`
+}
+
+// Helper types for JSONUnmarshal
+type auditLogItem AuditLogItem // Used to avoid recursion of JSONUnmarshal
+type auditLogItemUnmarshalHelper struct {
+	auditLogItem
+	Attributes    *auditLogItem `json:"attributes"`
+	Relationships struct {
+		Actor struct {
+			Data *User `json:"data"`
+		} `json:"actor"`
+	} `json:"relationships"`
+}
+
+// UnmarshalJSON allows JSONAPI attributes and relationships to unmarshal cleanly.
+func (a *AuditLogItem) UnmarshalJSON(b []byte) error {
+	var helper auditLogItemUnmarshalHelper
+	helper.Attributes = &helper.auditLogItem
+	if err := json.Unmarshal(b, &helper); err != nil {
+		return err
+	}
+	*a = AuditLogItem(helper.auditLogItem)
+	a.Actor = helper.Relationships.Actor.Data
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"bytes"
+	"encoding/json"
+	"text/template"
+)
+
+// CommonResponse represents a canned reply a program can post on reports.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#common-response
+type CommonResponse struct {
+	ID        *string    `json:"id"`
+	Type      *string    `json:"type"`
+	Title     *string    `json:"title"`
+	Message   *string    `json:"message"`
+	CreatedAt *Timestamp `json:"created_at"`
+	UpdatedAt *Timestamp `json:"updated_at"`
+}
+
+// Helper types for JSONUnmarshal
+type commonResponse CommonResponse // Used to avoid recursion of JSONUnmarshal
+type commonResponseUnmarshalHelper struct {
+	commonResponse
+	Attributes *commonResponse `json:"attributes"`
+}
+
+// UnmarshalJSON allows JSONAPI attributes and relationships to unmarshal cleanly.
+func (c *CommonResponse) UnmarshalJSON(b []byte) error {
+	var helper commonResponseUnmarshalHelper
+	helper.Attributes = &helper.commonResponse
+	if err := json.Unmarshal(b, &helper); err != nil {
+		return err
+	}
+	*c = CommonResponse(helper.commonResponse)
+	return nil
+}
+
+// CommonResponseVariables are the report fields available to a CommonResponse message template
+type CommonResponseVariables struct {
+	ReportID         string
+	ReportTitle      string
+	ReporterUsername string
+	ReporterName     string
+	ProgramHandle    string
+}
+
+// NewCommonResponseVariables extracts the template variables from a report. Missing fields are left empty.
+func NewCommonResponseVariables(report *Report) CommonResponseVariables {
+	var vars CommonResponseVariables
+	if report.ID != nil {
+		vars.ReportID = *report.ID
+	}
+	if report.Title != nil {
+		vars.ReportTitle = *report.Title
+	}
+	if report.Reporter != nil && report.Reporter.Username != nil {
+		vars.ReporterUsername = *report.Reporter.Username
+	}
+	if report.Reporter != nil && report.Reporter.Name != nil {
+		vars.ReporterName = *report.Reporter.Name
+	}
+	if report.Program != nil && report.Program.Handle != nil {
+		vars.ProgramHandle = *report.Program.Handle
+	}
+	return vars
+}
+
+// Render executes the message as a text/template against the fields of the report, e.g. "Thanks {{.ReporterUsername}}!"
+func (c *CommonResponse) Render(report *Report) (string, error) {
+	var message string
+	if c.Message != nil {
+		message = *c.Message
+	}
+	tmpl, err := template.New("common-response").Parse(message)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, NewCommonResponseVariables(report)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"testing"
+)
+
+var expectedCommonResponse = CommonResponse{
+	ID:        String("1337"),
+	Type:      String(CommonResponseType),
+	Title:     String("Thank you"),
+	Message:   String("Thanks for your report {{.ReporterUsername}}, the {{.ProgramHandle}} team is looking into \"{{.ReportTitle}}\"."),
+	CreatedAt: NewTimestamp("2016-02-02T04:05:06.000Z"),
+	UpdatedAt: NewTimestamp("2016-02-02T04:05:06.000Z"),
+}
+
+func Test_CommonResponse(t *testing.T) {
+	var actual CommonResponse
+	loadResource(t, &actual, "tests/resources/common-response.json")
+	assert.Equal(t, expectedCommonResponse, actual)
+}
+
+func Test_CommonResponse_Render(t *testing.T) {
+	// Verify that report fields are substituted
+	actual, err := expectedCommonResponse.Render(&expectedReport)
+	assert.Nil(t, err)
+	assert.Equal(t, "Thanks for your report api-example, the security team is looking into \"XSS in login form\".", actual)
+
+	// Verify that missing report fields render empty
+	actual, err = expectedCommonResponse.Render(&Report{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Thanks for your report , the  team is looking into \"\".", actual)
+
+	// Verify that an invalid template fails
+	_, err = (&CommonResponse{Message: String("{{.ReportTitle")}).Render(&expectedReport)
+	assert.NotNil(t, err)
+
+	// Verify that an unknown variable fails
+	_, err = (&CommonResponse{Message: String("{{.Unknown}}")}).Render(&expectedReport)
+	assert.NotNil(t, err)
+}
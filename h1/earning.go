@@ -0,0 +1,70 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"encoding/json"
+)
+
+// Earning represents an amount a hacker earned on a program.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#earning
+type Earning struct {
+	ID        *string    `json:"id"`
+	Type      *string    `json:"type"`
+	Amount    *string    `json:"amount"`
+	CreatedAt *Timestamp `json:"created_at"`
+	Program   *Program   `json:"program,omitempty"`
+	Bounty    *Bounty    `json:"bounty,omitempty"`
+	Report    *Report    `json:"report,omitempty"`
+}
+
+// Helper types for JSONUnmarshal
+type earning Earning // Used to avoid recursion of JSONUnmarshal
+type earningUnmarshalHelper struct {
+	earning
+	Attributes    *earning `json:"attributes"`
+	Relationships struct {
+		Program struct {
+			Data *Program `json:"data"`
+		} `json:"program"`
+		Bounty struct {
+			Data *Bounty `json:"data"`
+		} `json:"bounty"`
+		Report struct {
+			Data *Report `json:"data"`
+		} `json:"report"`
+	} `json:"relationships"`
+}
+
+// UnmarshalJSON allows JSONAPI attributes and relationships to unmarshal cleanly.
+func (e *Earning) UnmarshalJSON(b []byte) error {
+	var helper earningUnmarshalHelper
+	helper.Attributes = &helper.earning
+	if err := json.Unmarshal(b, &helper); err != nil {
+		return err
+	}
+	*e = Earning(helper.earning)
+	e.Program = helper.Relationships.Program.Data
+	e.Bounty = helper.Relationships.Bounty.Data
+	e.Report = helper.Relationships.Report.Data
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"testing"
+)
+
+var expectedEarning = Earning{
+	ID:        String("1337"),
+	Type:      String(EarningType),
+	Amount:    String("550.00"),
+	CreatedAt: NewTimestamp("2016-02-02T04:05:06.000Z"),
+	Program: &Program{
+		ID:        String("1337"),
+		Type:      String(ProgramType),
+		Handle:    String("security"),
+		CreatedAt: NewTimestamp("2016-02-02T04:05:06.000Z"),
+		UpdatedAt: NewTimestamp("2016-02-02T04:05:06.000Z"),
+	},
+	Bounty: &Bounty{
+		ID:          String("1337"),
+		Type:        String(BountyType),
+		Amount:      String("500.00"),
+		BonusAmount: String("50.00"),
+		CreatedAt:   NewTimestamp("2016-02-02T04:05:06.000Z"),
+	},
+	Report: &Report{
+		ID:        String("1337"),
+		Type:      String(ReportType),
+		Title:     String("XSS in login form"),
+		State:     String(ReportStateResolved),
+		CreatedAt: NewTimestamp("2016-02-02T04:05:06.000Z"),
+	},
+}
+
+func Test_Earning(t *testing.T) {
+	var actual Earning
+	loadResource(t, &actual, "tests/resources/earning.json")
+	assert.Equal(t, expectedEarning, actual)
+}
@@ -24,8 +24,10 @@ package h1
 import (
 	"github.com/google/go-querystring/query"
 
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -51,8 +53,12 @@ type Client struct {
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services used for talking to different parts of the H1 API.
-	Report  *ReportService
-	Program *ProgramService
+	Report     *ReportService
+	Program    *ProgramService
+	Analytics  *AnalyticsService
+	Hacker     *HackerService
+	Hacktivity *HacktivityService
+	User       *UserService
 }
 
 type service struct {
@@ -105,23 +111,38 @@ func NewClient(httpClient *http.Client) *Client {
 	c.common.client = c
 	c.Report = (*ReportService)(&c.common)
 	c.Program = (*ProgramService)(&c.common)
+	c.Analytics = (*AnalyticsService)(&c.common)
+	c.Hacker = (*HackerService)(&c.common)
+	c.Hacktivity = (*HacktivityService)(&c.common)
+	c.User = (*UserService)(&c.common)
 
 	return c
 }
 
-// NewRequest creates an API request. A relative URL can be provided in urlStr
+// NewRequest creates an API request. A relative URL can be provided in urlStr. If specified, the value pointed to by body is JSON encoded and included as the request body.
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
 	rel, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(method, c.BaseURL.ResolveReference(rel).String(), nil)
+	var buf io.ReadWriter
+	if body != nil {
+		buf = new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(body); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL.ResolveReference(rel).String(), buf)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Add("User-Agent", c.UserAgent)
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
 
 	return req, nil
 }
@@ -208,6 +229,26 @@ type responseWrapper struct {
 	Data interface{} `json:"data"`
 }
 
+// Used to build request bodies
+type requestWrapper struct {
+	Data requestData `json:"data"`
+}
+type requestData struct {
+	ID         string      `json:"id,omitempty"`
+	Type       string      `json:"type"`
+	Attributes interface{} `json:"attributes,omitempty"`
+}
+
+// newRequestBody wraps attributes in a JSONAPI document of the given type
+func newRequestBody(resourceType string, attributes interface{}) *requestWrapper {
+	return &requestWrapper{
+		Data: requestData{
+			Type:       resourceType,
+			Attributes: attributes,
+		},
+	}
+}
+
 // CheckResponse determines if the given http.Response was an error and converts it to a h1.ErrorResponse if so
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
@@ -243,7 +284,7 @@ func (c *Client) Do(req *http.Request, resource interface{}) (*Response, error)
 		Response: response,
 		Data:     resource,
 	}
-	if err := json.NewDecoder(resp.Body).Decode(wrapper); err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(wrapper); err != nil && err != io.EOF {
 		return response, err
 	}
 
@@ -171,6 +171,21 @@ func Test_NewRequest(t *testing.T) {
 	assert.Equal(t, expected, req)
 }
 
+func Test_NewRequest_Body(t *testing.T) {
+	// Check that an unencodable body fails
+	client := NewClient(nil)
+	_, err := client.NewRequest("POST", "/relativepath", make(chan int))
+	assert.NotNil(t, err)
+
+	// Check that a body is JSON encoded
+	req, err := client.NewRequest("POST", "/relativepath", newRequestBody(ActivityCommentType, map[string]string{"message": "Comment!"}))
+	assert.Nil(t, err)
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	body, err := ioutil.ReadAll(req.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"data":{"type":"activity-comment","attributes":{"message":"Comment!"}}}`+"\n", string(body))
+}
+
 func Test_Client_Do(t *testing.T) {
 	// Verify that an invalid request fails
 	client := NewClient(nil)
@@ -213,4 +228,16 @@ func Test_Client_Do(t *testing.T) {
 	}, nil)
 	assert.Nil(t, err)
 	assert.Equal(t, ResponseLinks{}, successResponse.Links)
+
+	// Verify that an empty response body is not an error
+	emptyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer emptyServer.Close()
+	u, err = url.Parse(emptyServer.URL)
+	assert.Nil(t, err)
+	_, err = client.Do(&http.Request{
+		URL: u,
+	}, nil)
+	assert.Nil(t, err)
 }
@@ -0,0 +1,161 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"fmt"
+)
+
+// HackerService handles communication with the hacker related methods of the H1 API. These methods act on behalf of the authenticated hacker rather than a program.
+type HackerService service
+
+// ListReports returns the reports submitted by the authenticated hacker
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#hacker-reports
+func (s *HackerService) ListReports(listOpts *ListOptions) ([]Report, *Response, error) {
+	u, err := addOptions("hackers/me/reports", nil, listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reports := new([]Report)
+	resp, err := s.client.Do(req, reports)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *reports, resp, err
+}
+
+// ListEarnings returns the earnings of the authenticated hacker
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#hacker-earnings
+func (s *HackerService) ListEarnings(listOpts *ListOptions) ([]Earning, *Response, error) {
+	u, err := addOptions("hackers/payments/earnings", nil, listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	earnings := new([]Earning)
+	resp, err := s.client.Do(req, earnings)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *earnings, resp, err
+}
+
+// ListPayouts returns the payouts made to the authenticated hacker
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#hacker-payouts
+func (s *HackerService) ListPayouts(listOpts *ListOptions) ([]Payout, *Response, error) {
+	u, err := addOptions("hackers/payments/payouts", nil, listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payouts := new([]Payout)
+	resp, err := s.client.Do(req, payouts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *payouts, resp, err
+}
+
+// ListPrograms returns the programs in the directory that are visible to the authenticated hacker
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#hacker-programs
+func (s *HackerService) ListPrograms(listOpts *ListOptions) ([]Program, *Response, error) {
+	u, err := addOptions("hackers/programs", nil, listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	programs := new([]Program)
+	resp, err := s.client.Do(req, programs)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *programs, resp, err
+}
+
+// GetProgram fetches a Program from the directory by handle
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#hacker-program
+func (s *HackerService) GetProgram(handle string) (*Program, *Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("hackers/programs/%s", handle), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(Program)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
+
+// ListStructuredScopes returns the structured scopes of a program in the directory by handle
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#hacker-program-structured-scopes
+func (s *HackerService) ListStructuredScopes(handle string, listOpts *ListOptions) ([]StructuredScope, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("hackers/programs/%s/structured_scopes", handle), nil, listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	structuredScopes := new([]StructuredScope)
+	resp, err := s.client.Do(req, structuredScopes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *structuredScopes, resp, err
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func Test_HackerService_ListReports(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{
+		Scheme: "http://[fe80::1%en0]/",
+	}
+	_, _, err := c.Hacker.ListReports(nil)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Hacker.ListReports(nil)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	reportServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hackers/me/reports", r.URL.Path)
+		http.ServeFile(w, r, "tests/responses/report_list.json")
+	}))
+	defer reportServer.Close()
+	u, err = url.Parse(reportServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Hacker.ListReports(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedReport, actual[0])
+}
+
+func Test_HackerService_ListEarnings(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{
+		Scheme: "http://[fe80::1%en0]/",
+	}
+	_, _, err := c.Hacker.ListEarnings(nil)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Hacker.ListEarnings(nil)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	earningServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hackers/payments/earnings", r.URL.Path)
+		http.ServeFile(w, r, "tests/responses/earning_list.json")
+	}))
+	defer earningServer.Close()
+	u, err = url.Parse(earningServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Hacker.ListEarnings(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []Earning{expectedEarning}, actual)
+}
+
+func Test_HackerService_ListPayouts(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{
+		Scheme: "http://[fe80::1%en0]/",
+	}
+	_, _, err := c.Hacker.ListPayouts(nil)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Hacker.ListPayouts(nil)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	payoutServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hackers/payments/payouts", r.URL.Path)
+		http.ServeFile(w, r, "tests/responses/payout_list.json")
+	}))
+	defer payoutServer.Close()
+	u, err = url.Parse(payoutServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Hacker.ListPayouts(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []Payout{expectedPayout}, actual)
+}
+
+func Test_HackerService_ListPrograms(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{
+		Scheme: "http://[fe80::1%en0]/",
+	}
+	_, _, err := c.Hacker.ListPrograms(nil)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Hacker.ListPrograms(nil)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	programServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hackers/programs", r.URL.Path)
+		http.ServeFile(w, r, "tests/responses/program_list.json")
+	}))
+	defer programServer.Close()
+	u, err = url.Parse(programServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Hacker.ListPrograms(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []Program{expectedHackerProgram}, actual)
+}
+
+func Test_HackerService_GetProgram(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Hacker.GetProgram("%A")
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Hacker.GetProgram("security")
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	programServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hackers/programs/security", r.URL.Path)
+		http.ServeFile(w, r, "tests/responses/program_hacker.json")
+	}))
+	defer programServer.Close()
+	u, err = url.Parse(programServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Hacker.GetProgram("security")
+	assert.Nil(t, err)
+	assert.Equal(t, &expectedHackerProgram, actual)
+}
+
+func Test_HackerService_ListStructuredScopes(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Hacker.ListStructuredScopes("%A", nil)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Hacker.ListStructuredScopes("security", nil)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	scopeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hackers/programs/security/structured_scopes", r.URL.Path)
+		http.ServeFile(w, r, "tests/responses/structured_scope_list.json")
+	}))
+	defer scopeServer.Close()
+	u, err = url.Parse(scopeServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Hacker.ListStructuredScopes("security", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []StructuredScope{expectedStructuredScope}, actual)
+}
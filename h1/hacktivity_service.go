@@ -0,0 +1,65 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"time"
+)
+
+// HacktivityService handles communication with the publicly disclosed reports of the H1 API.
+type HacktivityService service
+
+// HacktivityListFilter specifies optional parameters to the HacktivityService.List method.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#hacktivity
+type HacktivityListFilter struct {
+	Program                []string  `url:"program,brackets,omitempty"`
+	Weakness               []string  `url:"weakness,brackets,omitempty"`
+	Severity               []string  `url:"severity,brackets,omitempty"`
+	DisclosedAtGreaterThan time.Time `url:"disclosed_at__gt,omitempty"`
+	DisclosedAtLessThan    time.Time `url:"disclosed_at__lt,omitempty"`
+}
+
+// List returns all publicly disclosed Reports matching the specified criteria
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#hacktivity
+func (s *HacktivityService) List(filterOpts HacktivityListFilter, listOpts *ListOptions) ([]Report, *Response, error) {
+	opts := struct {
+		Filter HacktivityListFilter `url:"filter,brackets"`
+	}{
+		Filter: filterOpts,
+	}
+	// addOptions takes structs only so it can't fail
+	u, _ := addOptions("hacktivity", &opts, listOpts)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reports := new([]Report)
+	resp, err := s.client.Do(req, reports)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *reports, resp, err
+}
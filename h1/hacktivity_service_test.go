@@ -0,0 +1,118 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func Test_HacktivityService_List(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{
+		Scheme: "http://[fe80::1%en0]/",
+	}
+	_, _, err := c.Hacktivity.List(HacktivityListFilter{}, nil)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Hacktivity.List(HacktivityListFilter{}, nil)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	hacktivityServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hacktivity", r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, "security", query.Get("filter[program][]"))
+		assert.Equal(t, "cwe-79", query.Get("filter[weakness][]"))
+		assert.Equal(t, []string{SeverityRatingHigh, SeverityRatingMedium}, query["filter[severity][]"])
+		assert.Equal(t, "2016-01-01T00:00:00Z", query.Get("filter[disclosed_at__gt]"))
+		assert.Equal(t, "", query.Get("filter[disclosed_at__lt]"))
+		http.ServeFile(w, r, "tests/responses/hacktivity_list.json")
+	}))
+	defer hacktivityServer.Close()
+	u, err = url.Parse(hacktivityServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	filter := HacktivityListFilter{
+		Program:                []string{"security"},
+		Weakness:               []string{"cwe-79"},
+		Severity:               []string{SeverityRatingHigh, SeverityRatingMedium},
+		DisclosedAtGreaterThan: NewTimestamp("2016-01-01T00:00:00Z").Time,
+	}
+	actual, _, err := c.Hacktivity.List(filter, nil)
+	assert.Nil(t, err)
+	expected := []Report{
+		Report{
+			ID:          String("1337"),
+			Type:        String(ReportType),
+			Title:       String("XSS in login form"),
+			State:       String(ReportStateResolved),
+			CreatedAt:   NewTimestamp("2016-02-02T04:05:06.000Z"),
+			DisclosedAt: NewTimestamp("2016-03-02T04:05:06.000Z"),
+			Program: &Program{
+				ID:        String("1337"),
+				Type:      String(ProgramType),
+				Handle:    String("security"),
+				CreatedAt: NewTimestamp("2016-02-02T04:05:06.000Z"),
+				UpdatedAt: NewTimestamp("2016-02-02T04:05:06.000Z"),
+			},
+			Weakness: &Weakness{
+				ID:          String("1337"),
+				Type:        String(WeaknessType),
+				Name:        String("Cross-site Scripting (XSS) - Generic"),
+				Description: String("The software does not neutralize or incorrectly neutralizes user-controllable input before it is placed in output that is used as a web page that is served to other users."),
+				ExternalID:  String("cwe-79"),
+				CreatedAt:   NewTimestamp("2016-02-02T04:05:06.000Z"),
+			},
+			Severity: &Severity{
+				ID:         String("57"),
+				Type:       String(SeverityType),
+				Rating:     String(SeverityRatingHigh),
+				AuthorType: String(SeverityAuthorTypeUser),
+				CreatedAt:  NewTimestamp("2016-02-02T04:05:06.000Z"),
+			},
+			Summaries: []ReportSummary{
+				ReportSummary{
+					ID:        String("1337"),
+					Type:      String(ReportSummaryType),
+					Content:   String("There was a cross-site scripting vulnerability in our login form."),
+					Category:  String(ReportSummaryCategoryTeam),
+					CreatedAt: NewTimestamp("2016-02-02T04:05:06.000Z"),
+					UpdatedAt: NewTimestamp("2016-02-02T04:05:06.000Z"),
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"encoding/json"
+)
+
+// PaymentTransactionCategory represent possible categories for a payment transaction
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#payment-transaction
+const (
+	PaymentTransactionCategoryBounty string = "bounty"
+	PaymentTransactionCategoryBonus  string = "bonus"
+	PaymentTransactionCategoryFee    string = "fee"
+	PaymentTransactionCategoryTopUp  string = "top-up"
+)
+
+// PaymentTransaction represents a single movement of funds on a program's balance.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#payment-transaction
+type PaymentTransaction struct {
+	ID          *string    `json:"id"`
+	Type        *string    `json:"type"`
+	Category    *string    `json:"category"`
+	Amount      *string    `json:"amount"`
+	Currency    *string    `json:"currency"`
+	Description *string    `json:"description,omitempty"`
+	CreatedAt   *Timestamp `json:"created_at"`
+	Bounty      *Bounty    `json:"bounty,omitempty"`
+	Report      *Report    `json:"report,omitempty"`
+}
+
+// Helper types for JSONUnmarshal
+type paymentTransaction PaymentTransaction // Used to avoid recursion of JSONUnmarshal
+type paymentTransactionUnmarshalHelper struct {
+	paymentTransaction
+	Attributes    *paymentTransaction `json:"attributes"`
+	Relationships struct {
+		Bounty struct {
+			Data *Bounty `json:"data"`
+		} `json:"bounty"`
+		Report struct {
+			Data *Report `json:"data"`
+		} `json:"report"`
+	} `json:"relationships"`
+}
+
+// UnmarshalJSON allows JSONAPI attributes and relationships to unmarshal cleanly.
+func (p *PaymentTransaction) UnmarshalJSON(b []byte) error {
+	var helper paymentTransactionUnmarshalHelper
+	helper.Attributes = &helper.paymentTransaction
+	if err := json.Unmarshal(b, &helper); err != nil {
+		return err
+	}
+	*p = PaymentTransaction(helper.paymentTransaction)
+	p.Bounty = helper.Relationships.Bounty.Data
+	p.Report = helper.Relationships.Report.Data
+	return nil
+}
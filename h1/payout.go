@@ -0,0 +1,66 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"encoding/json"
+)
+
+// PayoutStatus represent possible statuses for a payout
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#payout
+const (
+	PayoutStatusPending   string = "pending"
+	PayoutStatusSent      string = "sent"
+	PayoutStatusCompleted string = "completed"
+	PayoutStatusFailed    string = "failed"
+)
+
+// Payout represents a transfer of earnings to a hacker.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#payout
+type Payout struct {
+	ID             *string    `json:"id"`
+	Type           *string    `json:"type"`
+	Amount         *string    `json:"amount"`
+	Status         *string    `json:"status"`
+	Reference      *string    `json:"reference,omitempty"`
+	PayoutProvider *string    `json:"payout_provider,omitempty"`
+	PaidOutAt      *Timestamp `json:"paid_out_at,omitempty"`
+}
+
+// Helper types for JSONUnmarshal
+type payout Payout // Used to avoid recursion of JSONUnmarshal
+type payoutUnmarshalHelper struct {
+	payout
+	Attributes *payout `json:"attributes"`
+}
+
+// UnmarshalJSON allows JSONAPI attributes and relationships to unmarshal cleanly.
+func (p *Payout) UnmarshalJSON(b []byte) error {
+	var helper payoutUnmarshalHelper
+	helper.Attributes = &helper.payout
+	if err := json.Unmarshal(b, &helper); err != nil {
+		return err
+	}
+	*p = Payout(helper.payout)
+	return nil
+}
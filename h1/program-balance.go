@@ -0,0 +1,53 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"encoding/json"
+)
+
+// ProgramBalance represents the funds available to a program for paying out bounties.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#program-balance
+type ProgramBalance struct {
+	ID       *string `json:"id"`
+	Type     *string `json:"type"`
+	Balance  *string `json:"balance"`
+	Currency *string `json:"currency"`
+}
+
+// Helper types for JSONUnmarshal
+type programBalance ProgramBalance // Used to avoid recursion of JSONUnmarshal
+type programBalanceUnmarshalHelper struct {
+	programBalance
+	Attributes *programBalance `json:"attributes"`
+}
+
+// UnmarshalJSON allows JSONAPI attributes and relationships to unmarshal cleanly.
+func (p *ProgramBalance) UnmarshalJSON(b []byte) error {
+	var helper programBalanceUnmarshalHelper
+	helper.Attributes = &helper.programBalance
+	if err := json.Unmarshal(b, &helper); err != nil {
+		return err
+	}
+	*p = ProgramBalance(helper.programBalance)
+	return nil
+}
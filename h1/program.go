@@ -28,13 +28,17 @@ import (
 //
 // HackerOne API docs: https://api.hackerone.com/docs/v1#program
 type Program struct {
-	ID        *string    `json:"id"`
-	Type      *string    `json:"type"`
-	Handle    *string    `json:"handle"`
-	CreatedAt *Timestamp `json:"created_at"`
-	UpdatedAt *Timestamp `json:"updated_at"`
-	Groups    []*Group   `json:"groups,omitempty"`
-	Members   []*Member  `json:"member,omitempty"`
+	ID               *string            `json:"id"`
+	Type             *string            `json:"type"`
+	Handle           *string            `json:"handle"`
+	Name             *string            `json:"name,omitempty"`
+	State            *string            `json:"state,omitempty"`
+	OffersBounties   *bool              `json:"offers_bounties,omitempty"`
+	CreatedAt        *Timestamp         `json:"created_at"`
+	UpdatedAt        *Timestamp         `json:"updated_at"`
+	Groups           []*Group           `json:"groups,omitempty"`
+	Members          []*Member          `json:"member,omitempty"`
+	StructuredScopes []*StructuredScope `json:"structured_scopes,omitempty"`
 }
 
 // Helper types for JSONUnmarshal
@@ -49,6 +53,9 @@ type programUnmarshalHelper struct {
 		Members struct {
 			Data []*Member `json:"data"`
 		} `json:"members"`
+		StructuredScopes struct {
+			Data []*StructuredScope `json:"data"`
+		} `json:"structured_scopes"`
 	} `json:"relationships"`
 }
 
@@ -62,5 +69,6 @@ func (p *Program) UnmarshalJSON(b []byte) error {
 	*p = Program(helper.program)
 	p.Groups = helper.Relationships.Groups.Data
 	p.Members = helper.Relationships.Members.Data
+	p.StructuredScopes = helper.Relationships.StructuredScopes.Data
 	return nil
 }
@@ -21,7 +21,9 @@
 package h1
 
 import (
+	"errors"
 	"fmt"
+	"time"
 )
 
 // ProgramService handles communication with the program related methods of the H1 API.
@@ -42,3 +44,188 @@ func (s *ProgramService) Get(ID string) (*Program, *Response, error) {
 
 	return rResp, resp, err
 }
+
+// ListWeaknesses returns the weaknesses a program's reports can be classified as
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#program-weaknesses
+func (s *ProgramService) ListWeaknesses(ID string, listOpts *ListOptions) ([]Weakness, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("programs/%s/weaknesses", ID), nil, listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	weaknesses := new([]Weakness)
+	resp, err := s.client.Do(req, weaknesses)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *weaknesses, resp, err
+}
+
+// ListCommonResponses returns the common responses (canned replies) of a program
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#program-common-responses
+func (s *ProgramService) ListCommonResponses(ID string, listOpts *ListOptions) ([]CommonResponse, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("programs/%s/common_responses", ID), nil, listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	commonResponses := new([]CommonResponse)
+	resp, err := s.client.Do(req, commonResponses)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *commonResponses, resp, err
+}
+
+// GetBalance fetches the current balance of a program
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#program-balance
+func (s *ProgramService) GetBalance(ID string) (*ProgramBalance, *Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("programs/%s/billing/balance", ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(ProgramBalance)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
+
+// ListPaymentTransactions returns the payment transactions of a program created between from and to. A zero from or to leaves that side of the range open.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#program-payment-transactions
+func (s *ProgramService) ListPaymentTransactions(ID string, from time.Time, to time.Time, listOpts *ListOptions) ([]PaymentTransaction, *Response, error) {
+	type paymentTransactionListFilter struct {
+		CreatedAtGreaterThan time.Time `url:"created_at__gt,omitempty"`
+		CreatedAtLessThan    time.Time `url:"created_at__lt,omitempty"`
+	}
+	opts := struct {
+		Filter paymentTransactionListFilter `url:"filter,brackets"`
+	}{
+		Filter: paymentTransactionListFilter{
+			CreatedAtGreaterThan: from,
+			CreatedAtLessThan:    to,
+		},
+	}
+	u, err := addOptions(fmt.Sprintf("programs/%s/billing/transactions", ID), &opts, listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transactions := new([]PaymentTransaction)
+	resp, err := s.client.Do(req, transactions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *transactions, resp, err
+}
+
+// AuditLogListFilter specifies optional parameters to the ProgramService.ListAuditLog method.
+type AuditLogListFilter struct {
+	CreatedAtGreaterThan time.Time `url:"created_at__gt,omitempty"`
+	CreatedAtLessThan    time.Time `url:"created_at__lt,omitempty"`
+}
+
+// ListAuditLog returns the audit log of a program matching the specified criteria
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#program-audit-log
+func (s *ProgramService) ListAuditLog(ID string, filterOpts AuditLogListFilter, listOpts *ListOptions) ([]AuditLogItem, *Response, error) {
+	opts := struct {
+		Filter AuditLogListFilter `url:"filter,brackets"`
+	}{
+		Filter: filterOpts,
+	}
+	u, err := addOptions(fmt.Sprintf("programs/%s/audit_log", ID), &opts, listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := new([]AuditLogItem)
+	resp, err := s.client.Do(req, items)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *items, resp, err
+}
+
+// ListReporters returns the hackers that have submitted reports to a program
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#program-reporters
+func (s *ProgramService) ListReporters(ID string, listOpts *ListOptions) ([]User, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("programs/%s/reporters", ID), nil, listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users := new([]User)
+	resp, err := s.client.Do(req, users)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *users, resp, err
+}
+
+// HackerInvitationOptions specifies the parameters to the ProgramService.InviteHacker method. One of Email or Username is required.
+type HackerInvitationOptions struct {
+	Email    string `json:"email,omitempty"`
+	Username string `json:"username,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// InviteHacker invites a hacker to a program by email address or username
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#program-invite-hacker
+func (s *ProgramService) InviteHacker(ID string, opts HackerInvitationOptions) (*HackerInvitation, *Response, error) {
+	if opts.Email == "" && opts.Username == "" {
+		return nil, nil, errors.New("an email or username is required to invite a hacker")
+	}
+
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("programs/%s/hacker_invitations", ID), newRequestBody(HackerInvitationType, &opts))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(HackerInvitation)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
@@ -23,10 +23,12 @@ package h1
 import (
 	"github.com/stretchr/testify/assert"
 
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 var expectedProgram = Program{
@@ -116,3 +118,278 @@ func Test_ProgramService_Get(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, &expectedProgram, actual)
 }
+
+func Test_ProgramService_ListWeaknesses(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Program.ListWeaknesses("%A", nil)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Program.ListWeaknesses("1337", nil)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	weaknessServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/programs/1337/weaknesses", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("page[number]"))
+		http.ServeFile(w, r, "tests/responses/weakness_list.json")
+	}))
+	defer weaknessServer.Close()
+	u, err = url.Parse(weaknessServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, resp, err := c.Program.ListWeaknesses("1337", &ListOptions{Page: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []Weakness{
+		Weakness{
+			ID:          String("1337"),
+			Type:        String(WeaknessType),
+			Name:        String("Cross-site Scripting (XSS) - Generic"),
+			Description: String("The software does not neutralize or incorrectly neutralizes user-controllable input before it is placed in output that is used as a web page that is served to other users."),
+			ExternalID:  String("cwe-79"),
+			CreatedAt:   NewTimestamp("2016-02-02T04:05:06.000Z"),
+		},
+	}, actual)
+	assert.Equal(t, uint64(2), resp.Links.NextPageNumber())
+}
+
+func Test_ProgramService_ListCommonResponses(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Program.ListCommonResponses("%A", nil)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Program.ListCommonResponses("1337", nil)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	commonResponseServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/programs/1337/common_responses", r.URL.Path)
+		http.ServeFile(w, r, "tests/responses/common_response_list.json")
+	}))
+	defer commonResponseServer.Close()
+	u, err = url.Parse(commonResponseServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Program.ListCommonResponses("1337", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []CommonResponse{expectedCommonResponse}, actual)
+}
+
+func Test_ProgramService_GetBalance(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Program.GetBalance("%A")
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Program.GetBalance("1337")
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	balanceServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/programs/1337/billing/balance", r.URL.Path)
+		http.ServeFile(w, r, "tests/responses/program_balance.json")
+	}))
+	defer balanceServer.Close()
+	u, err = url.Parse(balanceServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Program.GetBalance("1337")
+	assert.Nil(t, err)
+	assert.Equal(t, &ProgramBalance{
+		ID:       String("1337"),
+		Type:     String(ProgramBalanceType),
+		Balance:  String("1337.50"),
+		Currency: String("USD"),
+	}, actual)
+}
+
+func Test_ProgramService_ListPaymentTransactions(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Program.ListPaymentTransactions("%A", time.Time{}, time.Time{}, nil)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Program.ListPaymentTransactions("1337", time.Time{}, time.Time{}, nil)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	from := NewTimestamp("2016-02-01T00:00:00Z").Time
+	to := NewTimestamp("2016-03-01T00:00:00Z").Time
+	transactionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/programs/1337/billing/transactions", r.URL.Path)
+		assert.Equal(t, "2016-02-01T00:00:00Z", r.URL.Query().Get("filter[created_at__gt]"))
+		assert.Equal(t, "2016-03-01T00:00:00Z", r.URL.Query().Get("filter[created_at__lt]"))
+		http.ServeFile(w, r, "tests/responses/payment_transaction_list.json")
+	}))
+	defer transactionServer.Close()
+	u, err = url.Parse(transactionServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Program.ListPaymentTransactions("1337", from, to, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []PaymentTransaction{
+		expectedPaymentTransaction,
+		PaymentTransaction{
+			ID:        String("1338"),
+			Type:      String(PaymentTransactionType),
+			Category:  String(PaymentTransactionCategoryTopUp),
+			Amount:    String("10000.00"),
+			Currency:  String("USD"),
+			CreatedAt: NewTimestamp("2016-02-03T04:05:06.000Z"),
+		},
+	}, actual)
+}
+
+func Test_ProgramService_ListAuditLog(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Program.ListAuditLog("%A", AuditLogListFilter{}, nil)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Program.ListAuditLog("1337", AuditLogListFilter{}, nil)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	auditLogServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/programs/1337/audit_log", r.URL.Path)
+		assert.Equal(t, "2016-02-01T00:00:00Z", r.URL.Query().Get("filter[created_at__gt]"))
+		assert.Equal(t, "", r.URL.Query().Get("filter[created_at__lt]"))
+		assert.Equal(t, "100", r.URL.Query().Get("page[size]"))
+		http.ServeFile(w, r, "tests/responses/audit_log_list.json")
+	}))
+	defer auditLogServer.Close()
+	u, err = url.Parse(auditLogServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	filter := AuditLogListFilter{
+		CreatedAtGreaterThan: NewTimestamp("2016-02-01T00:00:00Z").Time,
+	}
+	actual, resp, err := c.Program.ListAuditLog("1337", filter, &ListOptions{PageSize: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, []AuditLogItem{expectedAuditLogItem}, actual)
+	assert.Equal(t, uint64(2), resp.Links.NextPageNumber())
+}
+
+func Test_ProgramService_ListReporters(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Program.ListReporters("%A", nil)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Program.ListReporters("1337", nil)
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	reporterServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/programs/1337/reporters", r.URL.Path)
+		http.ServeFile(w, r, "tests/responses/user_list.json")
+	}))
+	defer reporterServer.Close()
+	u, err = url.Parse(reporterServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Program.ListReporters("1337", nil)
+	assert.Nil(t, err)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "api-example", *actual[0].Username)
+	assert.Equal(t, uint64(7), *actual[0].Reputation)
+}
+
+func Test_ProgramService_InviteHacker(t *testing.T) {
+	// Verify that an email or username is required
+	c := NewClient(nil)
+	_, _, err := c.Program.InviteHacker("1337", HackerInvitationOptions{})
+	assert.NotNil(t, err)
+
+	// Verify that an invalid url fails
+	c.BaseURL = &url.URL{}
+	_, _, err = c.Program.InviteHacker("%A", HackerInvitationOptions{Username: "api-example"})
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Program.InviteHacker("1337", HackerInvitationOptions{Username: "api-example"})
+	assert.NotNil(t, err)
+
+	// Verify that it posts the invitation and parses the response correctly
+	var body string
+	invitationServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/programs/1337/hacker_invitations", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		http.ServeFile(w, r, "tests/responses/hacker_invitation.json")
+	}))
+	defer invitationServer.Close()
+	u, err = url.Parse(invitationServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Program.InviteHacker("1337", HackerInvitationOptions{
+		Email:   "hacker@example.com",
+		Message: "Welcome to our private program!",
+	})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"data":{"type":"hacker-invitation","attributes":{"email":"hacker@example.com","message":"Welcome to our private program!"}}}`, body)
+	assert.Equal(t, &expectedHackerInvitation, actual)
+}
@@ -87,3 +87,23 @@ func Test_Program(t *testing.T) {
 	}
 	assert.Equal(t, expected, actual)
 }
+
+var expectedHackerProgram = Program{
+	ID:             String("1337"),
+	Type:           String(ProgramType),
+	Handle:         String("security"),
+	Name:           String("Security"),
+	State:          String("public_mode"),
+	OffersBounties: Bool(true),
+	CreatedAt:      NewTimestamp("2016-02-02T04:05:06.000Z"),
+	UpdatedAt:      NewTimestamp("2016-02-02T04:05:06.000Z"),
+	StructuredScopes: []*StructuredScope{
+		&expectedStructuredScope,
+	},
+}
+
+func Test_Program_Hacker(t *testing.T) {
+	var actual Program
+	loadResource(t, &actual, "tests/resources/program_hacker.json")
+	assert.Equal(t, expectedHackerProgram, actual)
+}
@@ -64,6 +64,8 @@ type Report struct {
 	Attachments              []Attachment        `json:"attachments,omitempty"`
 	Swag                     []Swag              `json:"swag,omitempty"`
 	VulnerabilityTypes       []VulnerabilityType `json:"vulnerability_types"`
+	Weakness                 *Weakness           `json:"weakness,omitempty"`
+	StructuredScope          *StructuredScope    `json:"structured_scope,omitempty"`
 	Severity                 *Severity           `json:"severity,omitempty"`
 	Reporter                 *User               `json:"reporter,omitempty"`
 	Activities               []Activity          `json:"activities,omitempty"`
@@ -92,6 +94,12 @@ type reportUnmarshalHelper struct {
 		VulnerabilityTypes struct {
 			Data []VulnerabilityType `json:"data"`
 		} `json:"vulnerability_types"`
+		Weakness struct {
+			Data *Weakness `json:"data"`
+		} `json:"weakness"`
+		StructuredScope struct {
+			Data *StructuredScope `json:"data"`
+		} `json:"structured_scope"`
 		Severity struct {
 			Data *Severity `json:"data"`
 		} `json:"severity"`
@@ -123,6 +131,8 @@ func (r *Report) UnmarshalJSON(b []byte) error {
 	r.Attachments = helper.Relationships.Attachments.Data
 	r.Swag = helper.Relationships.Swag.Data
 	r.VulnerabilityTypes = helper.Relationships.VulnerabilityTypes.Data
+	r.Weakness = helper.Relationships.Weakness.Data
+	r.StructuredScope = helper.Relationships.StructuredScope.Data
 	r.Severity = helper.Relationships.Severity.Data
 	r.Reporter = helper.Relationships.Reporter.Data
 	r.Activities = helper.Relationships.Activities.Data
@@ -155,6 +165,27 @@ func (r *Report) Assignee() (assignee interface{}) {
 	return assignee
 }
 
+// CWEIDs returns the unique CWE IDs of the report's vulnerability types. Vulnerability types without a known CWE are skipped
+func (r *Report) CWEIDs() (ids []int) {
+	for _, vulnerabilityType := range r.VulnerabilityTypes {
+		id, ok := vulnerabilityType.CWEID()
+		if !ok {
+			continue
+		}
+		known := false
+		for _, existing := range ids {
+			if existing == id {
+				known = true
+				break
+			}
+		}
+		if !known {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // Helper function for Participants
 func appendUserIfMissing(slice []User, u User) []User {
 	for _, ele := range slice {
@@ -21,7 +21,9 @@
 package h1
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -49,6 +51,7 @@ func (s *ReportService) Get(ID string) (*Report, *Response, error) {
 // HackerOne API docs: https://api.hackerone.com/docs/v1#reports/query
 type ReportListFilter struct {
 	Program                           []string  `url:"program,brackets"`
+	Reporter                          []string  `url:"reporter,brackets,omitempty"`
 	State                             []string  `url:"state,brackets,omitempty"`
 	ID                                []uint64  `url:"id,brackets,omitempty"`
 	CreatedAtGreaterThan              time.Time `url:"created_at__gt,omitempty"`
@@ -105,3 +108,195 @@ func (s *ReportService) List(filterOpts ReportListFilter, listOpts *ListOptions)
 
 	return *reports, resp, err
 }
+
+// CreateComment posts a comment on a report. Internal comments are only visible to the program.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#report-create-comment
+func (s *ReportService) CreateComment(ID string, message string, internal bool) (*Activity, *Response, error) {
+	body := newRequestBody(ActivityCommentType, struct {
+		Message  string `json:"message"`
+		Internal bool   `json:"internal"`
+	}{
+		Message:  message,
+		Internal: internal,
+	})
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("reports/%s/activities", ID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(Activity)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
+
+// CreateCommonResponseComment renders a common response against the report and posts the result as a comment
+func (s *ReportService) CreateCommonResponseComment(report *Report, commonResponse *CommonResponse, internal bool) (*Activity, *Response, error) {
+	if report.ID == nil {
+		return nil, nil, errors.New("report must have an ID to post a comment")
+	}
+	message, err := commonResponse.Render(report)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.CreateComment(*report.ID, message, internal)
+}
+
+// UpdateIssueTrackerReferenceID links a report to an issue in the program's issue tracker. The message is posted with the resulting ActivityReferenceIDAdded.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#report-update-issue-tracker-reference-id
+func (s *ReportService) UpdateIssueTrackerReferenceID(ID string, reference string, message string) (*Activity, *Response, error) {
+	body := newRequestBody(IssueTrackerReferenceIDType, struct {
+		Reference string `json:"reference"`
+		Message   string `json:"message,omitempty"`
+	}{
+		Reference: reference,
+		Message:   message,
+	})
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("reports/%s/issue_tracker_reference_id", ID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(Activity)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
+
+// UpdateState changes the state of a report. The message is posted with the resulting activity, e.g. an ActivityBugResolved when moving to ReportStateResolved.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#report-state-change
+func (s *ReportService) UpdateState(ID string, state string, message string) (*Activity, *Response, error) {
+	body := newRequestBody(StateChangeType, struct {
+		State   string `json:"state"`
+		Message string `json:"message,omitempty"`
+	}{
+		State:   state,
+		Message: message,
+	})
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("reports/%s/state_changes", ID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(Activity)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
+
+// MarkDuplicate changes the state of a report to ReportStateDuplicate, referencing the original report. The message is posted with the resulting ActivityBugDuplicate.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#report-state-change
+func (s *ReportService) MarkDuplicate(ID string, originalID string, message string) (*Activity, *Response, error) {
+	original, err := strconv.ParseUint(originalID, 10, 64)
+	if err != nil {
+		return nil, nil, err
+	}
+	body := newRequestBody(StateChangeType, struct {
+		State            string `json:"state"`
+		OriginalReportID uint64 `json:"original_report_id"`
+		Message          string `json:"message,omitempty"`
+	}{
+		State:            ReportStateDuplicate,
+		OriginalReportID: original,
+		Message:          message,
+	})
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("reports/%s/state_changes", ID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(Activity)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
+
+// UpdateAssignee assigns a report to a user or group, or unassigns it when assigneeType is NobodyType. The assigneeID is ignored when unassigning. The message is posted with the resulting activity.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#report-assignee
+func (s *ReportService) UpdateAssignee(ID string, assigneeType string, assigneeID string, message string) (*Report, *Response, error) {
+	body := newRequestBody(assigneeType, struct {
+		Message string `json:"message,omitempty"`
+	}{
+		Message: message,
+	})
+	if assigneeType != NobodyType {
+		body.Data.ID = assigneeID
+	}
+	req, err := s.client.NewRequest("PUT", fmt.Sprintf("reports/%s/assignee", ID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(Report)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
+
+// UpdateSeverity sets the severity rating of a report
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#report-severity
+func (s *ReportService) UpdateSeverity(ID string, rating string) (*Severity, *Response, error) {
+	body := newRequestBody(SeverityType, struct {
+		Rating string `json:"rating"`
+	}{
+		Rating: rating,
+	})
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("reports/%s/severities", ID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(Severity)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
+
+// BanReporter bans the reporter of a report from the program. The returned activity is the resulting ActivityUserBannedFromProgram.
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#report-ban-reporter
+func (s *ReportService) BanReporter(ID string, userID string, reason string) (*Activity, *Response, error) {
+	body := newRequestBody(ActivityUserBannedFromProgramType, struct {
+		UserID string `json:"user_id"`
+		Reason string `json:"reason"`
+	}{
+		UserID: userID,
+		Reason: reason,
+	})
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("reports/%s/ban_reporter", ID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(Activity)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
@@ -23,6 +23,7 @@ package h1
 import (
 	"github.com/stretchr/testify/assert"
 
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -139,6 +140,299 @@ func Test_ReportService_List(t *testing.T) {
 
 }
 
+func Test_ReportService_CreateComment(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Report.CreateComment("%A", "Comment!", false)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Report.CreateComment("1337", "Comment!", false)
+	assert.NotNil(t, err)
+
+	// Verify that it posts the comment and parses the response correctly
+	var body string
+	commentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/reports/1337/activities", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		http.ServeFile(w, r, "tests/responses/activity.json")
+	}))
+	defer commentServer.Close()
+	u, err = url.Parse(commentServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Report.CreateComment("1337", "Comment!", true)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"data":{"type":"activity-comment","attributes":{"message":"Comment!","internal":true}}}`, body)
+	assert.Equal(t, "1337", *actual.ID)
+	assert.Equal(t, ActivityCommentType, *actual.Type)
+	assert.Equal(t, "Comment!", *actual.Message)
+
+	// Verify that a common response is rendered before posting
+	_, _, err = c.Report.CreateCommonResponseComment(&expectedReport, &expectedCommonResponse, false)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"data":{"type":"activity-comment","attributes":{"message":"Thanks for your report api-example, the security team is looking into \"XSS in login form\".","internal":false}}}`, body)
+
+	// Verify that an invalid common response fails
+	_, _, err = c.Report.CreateCommonResponseComment(&expectedReport, &CommonResponse{Message: String("{{")}, false)
+	assert.NotNil(t, err)
+
+	// Verify that a report without an ID fails without posting
+	body = ""
+	_, _, err = c.Report.CreateCommonResponseComment(&Report{}, &expectedCommonResponse, false)
+	assert.EqualError(t, err, "report must have an ID to post a comment")
+	assert.Equal(t, "", body)
+}
+
+func Test_ReportService_BanReporter(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Report.BanReporter("%A", "1337", "Spam")
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Report.BanReporter("1337", "1337", "Spam")
+	assert.NotNil(t, err)
+
+	// Verify that it posts the ban and parses the response correctly
+	var body string
+	banServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/reports/1337/ban_reporter", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		http.ServeFile(w, r, "tests/responses/activity_user_banned_from_program.json")
+	}))
+	defer banServer.Close()
+	u, err = url.Parse(banServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Report.BanReporter("1337", "1337", "Spam")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"data":{"type":"activity-user-banned-from-program","attributes":{"user_id":"1337","reason":"Spam"}}}`, body)
+	assert.Equal(t, ActivityUserBannedFromProgramType, *actual.Type)
+	banned := actual.Activity().(*ActivityUserBannedFromProgram)
+	assert.Equal(t, "1337", *banned.RemovedUser.ID)
+}
+
+func Test_ReportService_UpdateIssueTrackerReferenceID(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Report.UpdateIssueTrackerReferenceID("%A", "SEC-1", "")
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Report.UpdateIssueTrackerReferenceID("1337", "SEC-1", "")
+	assert.NotNil(t, err)
+
+	// Verify that it posts the reference and parses the response correctly
+	var body string
+	referenceServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/reports/1337/issue_tracker_reference_id", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		http.ServeFile(w, r, "tests/responses/activity_reference_id_added.json")
+	}))
+	defer referenceServer.Close()
+	u, err = url.Parse(referenceServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Report.UpdateIssueTrackerReferenceID("1337", "SEC-1", "Tracked in SEC-1")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"data":{"type":"issue-tracker-reference-id","attributes":{"reference":"SEC-1","message":"Tracked in SEC-1"}}}`, body)
+	assert.Equal(t, ActivityReferenceIDAddedType, *actual.Type)
+	added := actual.Activity().(*ActivityReferenceIDAdded)
+	assert.Equal(t, "SEC-1", *added.Reference)
+	assert.Equal(t, "https://jira.example.com/browse/SEC-1", *added.ReferenceURL)
+}
+
+func Test_ReportService_UpdateState(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Report.UpdateState("%A", ReportStateResolved, "")
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Report.UpdateState("1337", ReportStateResolved, "")
+	assert.NotNil(t, err)
+
+	// Verify that it posts the state and parses the response correctly
+	var body string
+	stateServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/reports/1337/state_changes", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		http.ServeFile(w, r, "tests/responses/activity_bug_resolved.json")
+	}))
+	defer stateServer.Close()
+	u, err = url.Parse(stateServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Report.UpdateState("1337", ReportStateResolved, "Fixed in SEC-1")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"data":{"type":"state-change","attributes":{"state":"resolved","message":"Fixed in SEC-1"}}}`, body)
+	assert.Equal(t, ActivityBugResolvedType, *actual.Type)
+	assert.Equal(t, "Fixed in SEC-1", *actual.Message)
+}
+
+func Test_ReportService_MarkDuplicate(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Report.MarkDuplicate("%A", "1336", "")
+	assert.NotNil(t, err)
+
+	// Verify that an invalid original report ID fails
+	_, _, err = c.Report.MarkDuplicate("1337", "abc", "")
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Report.MarkDuplicate("1337", "1336", "")
+	assert.NotNil(t, err)
+
+	// Verify that it posts the original report and parses the response correctly
+	var body string
+	duplicateServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/reports/1337/state_changes", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		http.ServeFile(w, r, "tests/responses/activity_bug_duplicate.json")
+	}))
+	defer duplicateServer.Close()
+	u, err = url.Parse(duplicateServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Report.MarkDuplicate("1337", "1336", "Duplicate of #1336")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"data":{"type":"state-change","attributes":{"state":"duplicate","original_report_id":1336,"message":"Duplicate of #1336"}}}`, body)
+	assert.Equal(t, ActivityBugDuplicateType, *actual.Type)
+	assert.Equal(t, "Duplicate of #1336", *actual.Message)
+}
+
+func Test_ReportService_UpdateAssignee(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Report.UpdateAssignee("%A", GroupType, "1", "")
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Report.UpdateAssignee("1337", GroupType, "1", "")
+	assert.NotNil(t, err)
+
+	// Verify that it puts the assignee and parses the response correctly
+	var body string
+	assigneeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/reports/1337/assignee", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		http.ServeFile(w, r, "tests/responses/report.json")
+	}))
+	defer assigneeServer.Close()
+	u, err = url.Parse(assigneeServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Report.UpdateAssignee("1337", GroupType, "42", "Assigned to the web team")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"data":{"id":"42","type":"group","attributes":{"message":"Assigned to the web team"}}}`, body)
+	assert.Equal(t, "1337", *actual.ID)
+
+	// Verify that unassigning doesn't send an ID
+	_, _, err = c.Report.UpdateAssignee("1337", NobodyType, "42", "")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"data":{"type":"nobody","attributes":{}}}`, body)
+}
+
+func Test_ReportService_UpdateSeverity(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.Report.UpdateSeverity("%A", SeverityRatingHigh)
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.Report.UpdateSeverity("1337", SeverityRatingHigh)
+	assert.NotNil(t, err)
+
+	// Verify that it posts the rating and parses the response correctly
+	var body string
+	severityServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/reports/1337/severities", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		http.ServeFile(w, r, "tests/responses/severity.json")
+	}))
+	defer severityServer.Close()
+	u, err = url.Parse(severityServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.Report.UpdateSeverity("1337", SeverityRatingHigh)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"data":{"type":"severity","attributes":{"rating":"high"}}}`, body)
+	assert.Equal(t, SeverityRatingHigh, *actual.Rating)
+	assert.Equal(t, "57", *actual.ID)
+}
+
 /*
 
 // List returns all Reports matching the specified criteria
@@ -52,6 +52,7 @@ func Test_Report(t *testing.T) {
 				CreatedAt:   NewTimestamp("2016-02-02T04:05:06.000Z"),
 			},
 		},
+		StructuredScope: &expectedStructuredScope,
 		Reporter: &User{
 			ID:       String("1337"),
 			Type:     String(UserType),
@@ -215,3 +216,16 @@ func Test_Report(t *testing.T) {
 		assigneeInvalidReport.Assignee()
 	}()
 }
+
+func Test_Report_CWEIDs(t *testing.T) {
+	report := Report{
+		VulnerabilityTypes: []VulnerabilityType{
+			VulnerabilityType{Name: String("Cross-Site Scripting (XSS)")},
+			VulnerabilityType{Name: String("Something Else")},
+			VulnerabilityType{Name: String("Remote Code Execution")},
+			VulnerabilityType{Name: String("Code Injection")},
+		},
+	}
+	assert.Equal(t, []int{79, 94}, report.CWEIDs())
+	assert.Nil(t, (&Report{}).CWEIDs())
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ReporterContext summarises the history of a report's reporter with the report's program
+type ReporterContext struct {
+	Reporter           *User             // The reporter the context is about
+	ReportCount        uint64            // The number of reports submitted to the program
+	ReportCountByState map[string]uint64 // The number of reports submitted to the program, by report state
+	BountyCount        uint64            // The number of bounties awarded by the program
+	BountyAmount       string            // The sum of bounties and bonuses awarded by the program
+	FirstReportAt      *Timestamp        // When the first report was submitted to the program
+	LastReportAt       *Timestamp        // When the last report was submitted to the program
+}
+
+// ReporterContext computes the history of the report's reporter with the report's program from all of the reporter's reports to that program
+func (s *ReportService) ReporterContext(report *Report) (*ReporterContext, error) {
+	if report.Reporter == nil || report.Reporter.Username == nil || report.Program == nil || report.Program.Handle == nil {
+		return nil, errors.New("report must have a reporter and program to compute a reporter context")
+	}
+
+	filter := ReportListFilter{
+		Program:  []string{*report.Program.Handle},
+		Reporter: []string{*report.Reporter.Username},
+	}
+	var listOpts ListOptions
+
+	var allReports []Report
+	for {
+		reports, resp, err := s.List(filter, &listOpts)
+		if err != nil {
+			return nil, err
+		}
+		allReports = append(allReports, reports...)
+		if resp.Links.Next == "" {
+			break
+		}
+		listOpts.Page = resp.Links.NextPageNumber()
+	}
+
+	reporterContext := &ReporterContext{
+		Reporter:           report.Reporter,
+		ReportCountByState: make(map[string]uint64),
+	}
+	bountyAmount := new(big.Rat)
+	for _, r := range allReports {
+		// Skip anything known to be submitted by someone else
+		if r.Reporter != nil && r.Reporter.ID != nil && report.Reporter.ID != nil && *r.Reporter.ID != *report.Reporter.ID {
+			continue
+		}
+
+		reporterContext.ReportCount++
+		if r.State != nil {
+			reporterContext.ReportCountByState[*r.State]++
+		}
+
+		if r.CreatedAt != nil {
+			if reporterContext.FirstReportAt == nil || r.CreatedAt.Before(reporterContext.FirstReportAt.Time) {
+				reporterContext.FirstReportAt = r.CreatedAt
+			}
+			if reporterContext.LastReportAt == nil || r.CreatedAt.After(reporterContext.LastReportAt.Time) {
+				reporterContext.LastReportAt = r.CreatedAt
+			}
+		}
+
+		for _, bounty := range r.Bounties {
+			reporterContext.BountyCount++
+			for _, amount := range []*string{bounty.Amount, bounty.BonusAmount} {
+				if amount == nil {
+					continue
+				}
+				value, ok := new(big.Rat).SetString(*amount)
+				if !ok {
+					return nil, errors.New("invalid bounty amount: " + *amount)
+				}
+				bountyAmount.Add(bountyAmount, value)
+			}
+		}
+	}
+	reporterContext.BountyAmount = bountyAmount.FloatString(2)
+
+	return reporterContext, nil
+}
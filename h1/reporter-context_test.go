@@ -0,0 +1,90 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func Test_ReportService_ReporterContext(t *testing.T) {
+	// Verify that a report without a reporter or program fails
+	c := NewClient(nil)
+	_, err := c.Report.ReporterContext(&Report{})
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, err = c.Report.ReporterContext(&expectedReport)
+	assert.NotNil(t, err)
+
+	// Verify that all pages are fetched and summarised
+	reportServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/reports", r.URL.Path)
+		assert.Equal(t, "security", r.URL.Query().Get("filter[program][]"))
+		assert.Equal(t, "api-example", r.URL.Query().Get("filter[reporter][]"))
+		if r.URL.Query().Get("page[number]") == "2" {
+			http.ServeFile(w, r, "tests/responses/reporter_context_page2.json")
+			return
+		}
+		http.ServeFile(w, r, "tests/responses/reporter_context_page1.json")
+	}))
+	defer reportServer.Close()
+	u, err = url.Parse(reportServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, err := c.Report.ReporterContext(&expectedReport)
+	assert.Nil(t, err)
+	expected := &ReporterContext{
+		Reporter:    expectedReport.Reporter,
+		ReportCount: 3,
+		ReportCountByState: map[string]uint64{
+			ReportStateResolved:  2,
+			ReportStateDuplicate: 1,
+		},
+		BountyCount:   3,
+		BountyAmount:  "650.15",
+		FirstReportAt: NewTimestamp("2016-01-02T04:05:06.000Z"),
+		LastReportAt:  NewTimestamp("2016-03-02T04:05:06.000Z"),
+	}
+	assert.Equal(t, expected, actual)
+
+	// Verify that an invalid bounty amount fails
+	invalidServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[{"id":"1","type":"report","attributes":{},"relationships":{"bounties":{"data":[{"id":"1","type":"bounty","attributes":{"amount":"lots"}}]}}}],"links":{}}`))
+	}))
+	defer invalidServer.Close()
+	u, err = url.Parse(invalidServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, err = c.Report.ReporterContext(&expectedReport)
+	assert.NotNil(t, err)
+}
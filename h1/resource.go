@@ -60,16 +60,28 @@ const (
 	ActivityUserBannedFromProgramType           string = "activity-user-banned-from-program"
 	AddressType                                 string = "address"
 	AttachmentType                              string = "attachment"
+	AuditLogItemType                            string = "audit-log-item"
 	BountyType                                  string = "bounty"
+	CommonResponseType                          string = "common-response"
+	EarningType                                 string = "earning"
 	GroupType                                   string = "group"
+	HackerInvitationType                        string = "hacker-invitation"
+	IssueTrackerReferenceIDType                 string = "issue-tracker-reference-id"
+	PaymentTransactionType                      string = "payment-transaction"
+	PayoutType                                  string = "payout"
 	ProgramType                                 string = "program"
+	ProgramBalanceType                          string = "program-balance"
 	ReportSummaryType                           string = "report-summary"
 	MemberType                                  string = "member"
+	NobodyType                                  string = "nobody"
 	ReportType                                  string = "report"
 	SwagType                                    string = "swag"
 	SeverityType                                string = "severity"
+	StateChangeType                             string = "state-change"
+	StructuredScopeType                         string = "structured-scope"
 	UserType                                    string = "user"
 	VulnerabilityTypeType                       string = "vulnerability-type"
+	WeaknessType                                string = "weakness"
 )
 
 // Bool allocates a new bool value to store v at and returns a pointer to it.
@@ -0,0 +1,64 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"fmt"
+)
+
+// UserService handles communication with the user related methods of the H1 API.
+type UserService service
+
+// Get fetches a User by username
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#user
+func (s *UserService) Get(username string) (*User, *Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("users/%s", username), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(User)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
+
+// GetByID fetches a User by ID
+//
+// HackerOne API docs: https://api.hackerone.com/docs/v1#user
+func (s *UserService) GetByID(ID string) (*User, *Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("users/id/%s", ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rResp := new(User)
+	resp, err := s.client.Do(req, rResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rResp, resp, err
+}
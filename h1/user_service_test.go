@@ -0,0 +1,112 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+var expectedUser = User{
+	ID:       String("1337"),
+	Type:     String(UserType),
+	Disabled: Bool(false),
+	Username: String("api-example"),
+	Name:     String("API Example"),
+	ProfilePicture: UserProfilePicture{
+		Size62x62:   String("/assets/avatars/default.png"),
+		Size82x82:   String("/assets/avatars/default.png"),
+		Size110x110: String("/assets/avatars/default.png"),
+		Size260x260: String("/assets/avatars/default.png"),
+	},
+	Reputation: Uint64(7),
+	Signal:     Float64(7.0),
+	Impact:     Float64(30.0),
+	CreatedAt:  NewTimestamp("2016-02-02T04:05:06.000Z"),
+}
+
+func Test_UserService_Get(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.User.Get("%A")
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.User.Get("api-example")
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	userServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users/api-example", r.URL.Path)
+		http.ServeFile(w, r, "tests/responses/user.json")
+	}))
+	defer userServer.Close()
+	u, err = url.Parse(userServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.User.Get("api-example")
+	assert.Nil(t, err)
+	assert.Equal(t, &expectedUser, actual)
+}
+
+func Test_UserService_GetByID(t *testing.T) {
+	// Verify that an invalid url fails
+	c := NewClient(nil)
+	c.BaseURL = &url.URL{}
+	_, _, err := c.User.GetByID("%A")
+	assert.NotNil(t, err)
+
+	// Verify that an error response fails
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Oh No", 500)
+	}))
+	defer errorServer.Close()
+	u, err := url.Parse(errorServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	_, _, err = c.User.GetByID("1337")
+	assert.NotNil(t, err)
+
+	// Verify that it gets a response correctly
+	userServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users/id/1337", r.URL.Path)
+		http.ServeFile(w, r, "tests/responses/user.json")
+	}))
+	defer userServer.Close()
+	u, err = url.Parse(userServer.URL)
+	assert.Nil(t, err)
+	c.BaseURL = u
+	actual, _, err := c.User.GetByID("1337")
+	assert.Nil(t, err)
+	assert.Equal(t, &expectedUser, actual)
+}
@@ -52,3 +52,36 @@ func (v *VulnerabilityType) UnmarshalJSON(b []byte) error {
 	*v = VulnerabilityType(helper.vulnerabilityType)
 	return nil
 }
+
+// vulnerabilityTypeCWEIDs maps the names of vulnerability types to their closest CWE ID
+var vulnerabilityTypeCWEIDs = map[string]int{
+	"Authentication Bypass":                   287,
+	"Brute Force":                             307,
+	"Clickjacking":                            1021,
+	"Code Injection":                          94,
+	"Command Injection":                       77,
+	"Cross-Site Request Forgery (CSRF)":       352,
+	"Cross-Site Scripting (XSS)":              79,
+	"Cryptographic Issue":                     310,
+	"Denial of Service":                       400,
+	"HTTP Response Splitting":                 113,
+	"Information Disclosure":                  200,
+	"Insecure Direct Object Reference (IDOR)": 639,
+	"Memory Corruption":                       119,
+	"Open Redirect":                           601,
+	"Path Traversal":                          22,
+	"Privilege Escalation":                    269,
+	"Remote Code Execution":                   94,
+	"Server-Side Request Forgery (SSRF)":      918,
+	"SQL Injection":                           89,
+	"XML External Entities (XXE)":             611,
+}
+
+// CWEID returns the CWE ID closest to the vulnerability type. It returns false if the vulnerability type has no known mapping.
+func (v *VulnerabilityType) CWEID() (int, bool) {
+	if v.Name == nil {
+		return 0, false
+	}
+	id, ok := vulnerabilityTypeCWEIDs[*v.Name]
+	return id, ok
+}
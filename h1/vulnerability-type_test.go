@@ -38,3 +38,16 @@ func Test_VulnerabilityType(t *testing.T) {
 	}
 	assert.Equal(t, expected, actual)
 }
+
+func Test_VulnerabilityType_CWEID(t *testing.T) {
+	// Verify that a known vulnerability type maps to a CWE
+	id, ok := (&VulnerabilityType{Name: String("Cross-Site Scripting (XSS)")}).CWEID()
+	assert.True(t, ok)
+	assert.Equal(t, 79, id)
+
+	// Verify that unknown vulnerability types do not map
+	_, ok = (&VulnerabilityType{Name: String("Something Else")}).CWEID()
+	assert.False(t, ok)
+	_, ok = (&VulnerabilityType{}).CWEID()
+	assert.False(t, ok)
+}
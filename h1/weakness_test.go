@@ -0,0 +1,56 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package h1
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"testing"
+)
+
+func Test_Weakness(t *testing.T) {
+	var actual Weakness
+	loadResource(t, &actual, "tests/resources/weakness.json")
+	expected := Weakness{
+		ID:          String("1337"),
+		Type:        String(WeaknessType),
+		Name:        String("Cross-site Scripting (XSS) - Generic"),
+		Description: String("The software does not neutralize or incorrectly neutralizes user-controllable input before it is placed in output that is used as a web page that is served to other users."),
+		ExternalID:  String("cwe-79"),
+		CreatedAt:   NewTimestamp("2016-02-02T04:05:06.000Z"),
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Weakness_CWEID(t *testing.T) {
+	// Verify that a CWE external ID is parsed
+	id, ok := (&Weakness{ExternalID: String("cwe-79")}).CWEID()
+	assert.True(t, ok)
+	assert.Equal(t, 79, id)
+
+	// Verify that other external IDs are rejected
+	_, ok = (&Weakness{ExternalID: String("capec-63")}).CWEID()
+	assert.False(t, ok)
+	_, ok = (&Weakness{ExternalID: String("cwe-abc")}).CWEID()
+	assert.False(t, ok)
+	_, ok = (&Weakness{}).CWEID()
+	assert.False(t, ok)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package metrics computes response time metrics and SLA breaches from the timestamps of h1.Report objects.
+package metrics
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+
+	"sort"
+	"time"
+)
+
+// Metric represent the response times that can be computed for a report
+const (
+	MetricTimeToFirstResponse string = "time-to-first-response"
+	MetricTimeToTriage        string = "time-to-triage"
+	MetricTimeToBounty        string = "time-to-bounty"
+	MetricTimeToResolution    string = "time-to-resolution"
+	MetricTimeSinceResponse   string = "time-since-response"
+)
+
+// Metrics lists every metric that can be computed
+var Metrics = []string{
+	MetricTimeToFirstResponse,
+	MetricTimeToTriage,
+	MetricTimeToBounty,
+	MetricTimeToResolution,
+	MetricTimeSinceResponse,
+}
+
+// Elapsed returns how long a metric took for a report. If the metric is still pending, the time elapsed until now is returned and done is false. If the metric does not apply to the report, ok is false.
+//
+// A metric stops being pending once the report is closed, except for time-to-bounty which is only pending for resolved reports.
+// Time-since-response is measured from the last program activity, or creation if there is none, and is never done.
+func Elapsed(report *h1.Report, metric string, now time.Time) (elapsed time.Duration, done bool, ok bool) {
+	if report.CreatedAt == nil {
+		return 0, false, false
+	}
+
+	var end *h1.Timestamp
+	pending := report.ClosedAt == nil
+	switch metric {
+	case MetricTimeToFirstResponse:
+		end = report.FirstProgramActivityAt
+	case MetricTimeToTriage:
+		end = report.TriagedAt
+	case MetricTimeToBounty:
+		end = report.BountyAwardedAt
+		pending = report.State != nil && *report.State == h1.ReportStateResolved
+	case MetricTimeToResolution:
+		end = report.ClosedAt
+	case MetricTimeSinceResponse:
+		if !pending {
+			return 0, false, false
+		}
+		if report.LastProgramActivityAt != nil {
+			return now.Sub(report.LastProgramActivityAt.Time), false, true
+		}
+	default:
+		return 0, false, false
+	}
+
+	if end != nil {
+		return end.Sub(report.CreatedAt.Time), true, true
+	}
+	if pending {
+		return now.Sub(report.CreatedAt.Time), false, true
+	}
+	return 0, false, false
+}
+
+// Summary describes the distribution of a set of durations
+type Summary struct {
+	Count int
+	Min   time.Duration
+	Max   time.Duration
+	Mean  time.Duration
+	P50   time.Duration
+	P90   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+}
+
+// Summarize computes the summary of a set of durations. Percentiles use the nearest-rank method.
+func Summarize(durations []time.Duration) Summary {
+	if len(durations) == 0 {
+		return Summary{}
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, duration := range sorted {
+		total += duration
+	}
+
+	return Summary{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Max:   sorted[len(sorted)-1],
+		Mean:  total / time.Duration(len(sorted)),
+		P50:   percentile(sorted, 50),
+		P90:   percentile(sorted, 90),
+		P95:   percentile(sorted, 95),
+		P99:   percentile(sorted, 99),
+	}
+}
+
+// percentile returns the nearest-rank percentile p of sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
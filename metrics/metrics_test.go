@@ -0,0 +1,113 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/hackeroni/h1"
+
+	"testing"
+	"time"
+)
+
+func Test_Elapsed(t *testing.T) {
+	now := h1.NewTimestamp("2016-02-10T00:00:00Z").Time
+	report := h1.Report{
+		State:                  h1.String(h1.ReportStateTriaged),
+		CreatedAt:              h1.NewTimestamp("2016-02-01T00:00:00Z"),
+		FirstProgramActivityAt: h1.NewTimestamp("2016-02-01T02:00:00Z"),
+		TriagedAt:              h1.NewTimestamp("2016-02-02T00:00:00Z"),
+	}
+
+	// Verify that completed metrics are measured from creation
+	elapsed, done, ok := Elapsed(&report, MetricTimeToFirstResponse, now)
+	assert.Equal(t, 2*time.Hour, elapsed)
+	assert.True(t, done)
+	assert.True(t, ok)
+	elapsed, done, ok = Elapsed(&report, MetricTimeToTriage, now)
+	assert.Equal(t, 24*time.Hour, elapsed)
+	assert.True(t, done)
+	assert.True(t, ok)
+
+	// Verify that pending metrics are measured until now
+	elapsed, done, ok = Elapsed(&report, MetricTimeToResolution, now)
+	assert.Equal(t, 9*24*time.Hour, elapsed)
+	assert.False(t, done)
+	assert.True(t, ok)
+
+	// Verify that time since response is measured from the last program activity
+	report.LastProgramActivityAt = h1.NewTimestamp("2016-02-09T00:00:00Z")
+	elapsed, done, ok = Elapsed(&report, MetricTimeSinceResponse, now)
+	assert.Equal(t, 24*time.Hour, elapsed)
+	assert.False(t, done)
+	assert.True(t, ok)
+
+	// Verify that bounties are only pending once resolved
+	_, _, ok = Elapsed(&report, MetricTimeToBounty, now)
+	assert.False(t, ok)
+	report.State = h1.String(h1.ReportStateResolved)
+	report.ClosedAt = h1.NewTimestamp("2016-02-05T00:00:00Z")
+	elapsed, done, ok = Elapsed(&report, MetricTimeToBounty, now)
+	assert.Equal(t, 9*24*time.Hour, elapsed)
+	assert.False(t, done)
+	assert.True(t, ok)
+
+	// Verify that closed reports are no longer pending
+	report.TriagedAt = nil
+	_, _, ok = Elapsed(&report, MetricTimeToTriage, now)
+	assert.False(t, ok)
+	_, _, ok = Elapsed(&report, MetricTimeSinceResponse, now)
+	assert.False(t, ok)
+
+	// Verify that unknown metrics and reports without a creation time do not apply
+	_, _, ok = Elapsed(&report, "unknown", now)
+	assert.False(t, ok)
+	_, _, ok = Elapsed(&h1.Report{}, MetricTimeToTriage, now)
+	assert.False(t, ok)
+}
+
+func Test_Summarize(t *testing.T) {
+	// Verify that an empty set is empty
+	assert.Equal(t, Summary{}, Summarize(nil))
+
+	// Verify that the summary is computed regardless of order
+	var durations []time.Duration
+	for i := 100; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Minute)
+	}
+	expected := Summary{
+		Count: 100,
+		Min:   time.Minute,
+		Max:   100 * time.Minute,
+		Mean:  50*time.Minute + 30*time.Second,
+		P50:   50 * time.Minute,
+		P90:   90 * time.Minute,
+		P95:   95 * time.Minute,
+		P99:   99 * time.Minute,
+	}
+	assert.Equal(t, expected, Summarize(durations))
+	assert.Equal(t, 100*time.Minute, durations[0])
+
+	// Verify that a single value is every percentile
+	single := Summarize([]time.Duration{time.Hour})
+	assert.Equal(t, time.Hour, single.P50)
+	assert.Equal(t, time.Hour, single.P99)
+}
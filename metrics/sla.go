@@ -0,0 +1,71 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+
+	"time"
+)
+
+// Target is the maximum time a metric may take for reports of a severity rating
+type Target struct {
+	Metric   string        // The metric the target applies to
+	Severity string        // The severity rating the target applies to, an empty string applies to all ratings
+	Duration time.Duration // The maximum time the metric may take
+}
+
+// Applies returns whether the target applies to the report
+func (t Target) Applies(report *h1.Report) bool {
+	return t.Severity == "" || t.Severity == SeverityRating(report)
+}
+
+// Breach is a report which exceeded, or is still pending and has exceeded, a target
+type Breach struct {
+	Report  *h1.Report
+	Target  Target
+	Elapsed time.Duration // How long the metric took, or has taken so far if Pending
+	Pending bool          // If the metric is still pending
+}
+
+// Breaches returns every report and target where the metric exceeded the target as of now
+func Breaches(reports []h1.Report, targets []Target, now time.Time) []Breach {
+	var breaches []Breach
+	for idx := range reports {
+		report := &reports[idx]
+		for _, target := range targets {
+			if !target.Applies(report) {
+				continue
+			}
+			elapsed, done, ok := Elapsed(report, target.Metric, now)
+			if !ok || elapsed <= target.Duration {
+				continue
+			}
+			breaches = append(breaches, Breach{
+				Report:  report,
+				Target:  target,
+				Elapsed: elapsed,
+				Pending: !done,
+			})
+		}
+	}
+	return breaches
+}
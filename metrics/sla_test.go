@@ -0,0 +1,64 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/hackeroni/h1"
+
+	"testing"
+	"time"
+)
+
+func Test_Breaches(t *testing.T) {
+	now := h1.NewTimestamp("2016-02-03T00:00:00Z").Time
+	reports := []h1.Report{
+		// Responded to in time
+		h1.Report{
+			CreatedAt:              h1.NewTimestamp("2016-02-01T00:00:00Z"),
+			FirstProgramActivityAt: h1.NewTimestamp("2016-02-01T01:00:00Z"),
+			TriagedAt:              h1.NewTimestamp("2016-02-01T02:00:00Z"),
+			Severity:               &h1.Severity{Rating: h1.String(h1.SeverityRatingHigh)},
+		},
+		// Responded to late
+		h1.Report{
+			CreatedAt:              h1.NewTimestamp("2016-02-01T00:00:00Z"),
+			FirstProgramActivityAt: h1.NewTimestamp("2016-02-01T05:00:00Z"),
+			TriagedAt:              h1.NewTimestamp("2016-02-01T06:00:00Z"),
+			Severity:               &h1.Severity{Rating: h1.String(h1.SeverityRatingHigh)},
+		},
+		// Not responded to yet
+		h1.Report{
+			CreatedAt: h1.NewTimestamp("2016-02-02T00:00:00Z"),
+		},
+	}
+	targets := []Target{
+		Target{Metric: MetricTimeToFirstResponse, Severity: h1.SeverityRatingHigh, Duration: 4 * time.Hour},
+		Target{Metric: MetricTimeToFirstResponse, Duration: 48 * time.Hour},
+		Target{Metric: MetricTimeToTriage, Duration: 12 * time.Hour},
+	}
+	actual := Breaches(reports, targets, now)
+	expected := []Breach{
+		Breach{Report: &reports[1], Target: targets[0], Elapsed: 5 * time.Hour},
+		Breach{Report: &reports[2], Target: targets[2], Elapsed: 24 * time.Hour, Pending: true},
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+
+	"time"
+)
+
+// Keys used when a report has no severity rating or assignee
+const (
+	Unrated    string = "unrated"
+	Unassigned string = "unassigned"
+)
+
+// Stats holds the summary of each metric, keyed by metric
+type Stats map[string]Summary
+
+// Breakdown holds the stats of a set of reports overall, per severity rating and per assignee
+type Breakdown struct {
+	Overall    Stats
+	BySeverity map[string]Stats // Keyed by severity rating, or Unrated
+	ByAssignee map[string]Stats // Keyed by user username or group name, or Unassigned
+}
+
+// Compute summarises the completed metrics of each report. Pending metrics are not included.
+func Compute(reports []h1.Report) *Breakdown {
+	overall := make(map[string][]time.Duration)
+	bySeverity := make(map[string]map[string][]time.Duration)
+	byAssignee := make(map[string]map[string][]time.Duration)
+
+	for idx := range reports {
+		report := &reports[idx]
+		severity := SeverityRating(report)
+		assignee := AssigneeName(report)
+		for _, metric := range Metrics {
+			elapsed, done, ok := Elapsed(report, metric, time.Time{})
+			if !ok || !done {
+				continue
+			}
+			overall[metric] = append(overall[metric], elapsed)
+			if bySeverity[severity] == nil {
+				bySeverity[severity] = make(map[string][]time.Duration)
+			}
+			bySeverity[severity][metric] = append(bySeverity[severity][metric], elapsed)
+			if byAssignee[assignee] == nil {
+				byAssignee[assignee] = make(map[string][]time.Duration)
+			}
+			byAssignee[assignee][metric] = append(byAssignee[assignee][metric], elapsed)
+		}
+	}
+
+	breakdown := &Breakdown{
+		Overall:    summarizeAll(overall),
+		BySeverity: make(map[string]Stats),
+		ByAssignee: make(map[string]Stats),
+	}
+	for severity, durations := range bySeverity {
+		breakdown.BySeverity[severity] = summarizeAll(durations)
+	}
+	for assignee, durations := range byAssignee {
+		breakdown.ByAssignee[assignee] = summarizeAll(durations)
+	}
+	return breakdown
+}
+
+// summarizeAll summarises the durations of each metric
+func summarizeAll(durations map[string][]time.Duration) Stats {
+	stats := make(Stats)
+	for metric, values := range durations {
+		stats[metric] = Summarize(values)
+	}
+	return stats
+}
+
+// SeverityRating returns the severity rating of a report, or Unrated
+func SeverityRating(report *h1.Report) string {
+	if report.Severity == nil || report.Severity.Rating == nil {
+		return Unrated
+	}
+	return *report.Severity.Rating
+}
+
+// AssigneeName returns the username or group name of the report's assignee, or Unassigned
+func AssigneeName(report *h1.Report) string {
+	if len(report.RawAssignee) == 0 {
+		return Unassigned
+	}
+	switch assignee := report.Assignee().(type) {
+	case *h1.User:
+		if assignee.Username != nil {
+			return *assignee.Username
+		}
+	case *h1.Group:
+		if assignee.Name != nil {
+			return *assignee.Name
+		}
+	}
+	return Unassigned
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/hackeroni/h1"
+
+	"testing"
+	"time"
+)
+
+func Test_Compute(t *testing.T) {
+	reports := []h1.Report{
+		h1.Report{
+			CreatedAt:              h1.NewTimestamp("2016-02-01T00:00:00Z"),
+			FirstProgramActivityAt: h1.NewTimestamp("2016-02-01T01:00:00Z"),
+			Severity:               &h1.Severity{Rating: h1.String(h1.SeverityRatingHigh)},
+			RawAssignee:            []byte(`{"id":"1","type":"user","attributes":{"username":"api-example"}}`),
+		},
+		h1.Report{
+			CreatedAt:              h1.NewTimestamp("2016-02-01T00:00:00Z"),
+			FirstProgramActivityAt: h1.NewTimestamp("2016-02-01T03:00:00Z"),
+			TriagedAt:              h1.NewTimestamp("2016-02-02T00:00:00Z"),
+			RawAssignee:            []byte(`{"id":"2","type":"group","attributes":{"name":"Admin"}}`),
+		},
+	}
+	actual := Compute(reports)
+
+	assert.Equal(t, Stats{
+		MetricTimeToFirstResponse: Summarize([]time.Duration{time.Hour, 3 * time.Hour}),
+		MetricTimeToTriage:        Summarize([]time.Duration{24 * time.Hour}),
+	}, actual.Overall)
+	assert.Equal(t, map[string]Stats{
+		h1.SeverityRatingHigh: Stats{
+			MetricTimeToFirstResponse: Summarize([]time.Duration{time.Hour}),
+		},
+		Unrated: Stats{
+			MetricTimeToFirstResponse: Summarize([]time.Duration{3 * time.Hour}),
+			MetricTimeToTriage:        Summarize([]time.Duration{24 * time.Hour}),
+		},
+	}, actual.BySeverity)
+	assert.Equal(t, map[string]Stats{
+		"api-example": Stats{
+			MetricTimeToFirstResponse: Summarize([]time.Duration{time.Hour}),
+		},
+		"Admin": Stats{
+			MetricTimeToFirstResponse: Summarize([]time.Duration{3 * time.Hour}),
+			MetricTimeToTriage:        Summarize([]time.Duration{24 * time.Hour}),
+		},
+	}, actual.ByAssignee)
+}
+
+func Test_AssigneeName(t *testing.T) {
+	assert.Equal(t, Unassigned, AssigneeName(&h1.Report{}))
+	assert.Equal(t, Unassigned, AssigneeName(&h1.Report{RawAssignee: []byte(`{}`)}))
+	assert.Equal(t, Unassigned, AssigneeName(&h1.Report{RawAssignee: []byte(`{"id":"1","type":"user","attributes":{}}`)}))
+	assert.Equal(t, "api-example", AssigneeName(&h1.Report{RawAssignee: []byte(`{"id":"1","type":"user","attributes":{"username":"api-example"}}`)}))
+}
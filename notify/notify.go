@@ -0,0 +1,158 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package notify sends polling.Events to chat, webhooks and email, formatted with text/template and routed by event type.
+package notify
+
+import (
+	"github.com/uber-go/hackeroni/polling"
+
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// reportURLPrefix is where reports can be viewed on HackerOne
+const reportURLPrefix = "https://hackerone.com/reports/"
+
+// Message is an event formatted for sending
+type Message struct {
+	Event   polling.Event
+	Subject string
+	Body    string
+}
+
+// Sink sends messages somewhere
+type Sink interface {
+	Send(message Message) error
+}
+
+// TemplateData is what templates are executed against. The event's fields, such as .Type, .Report and .Activity, are available directly
+type TemplateData struct {
+	polling.Event
+	URL string // The URL of the report on HackerOne, empty if there is no report
+}
+
+// Template formats events as messages using text/template
+type Template struct {
+	Subject *template.Template
+	Body    *template.Template
+}
+
+// NewTemplate parses the subject and body templates, e.g. "New comment on {{.Report.Title}}"
+func NewTemplate(subject string, body string) (*Template, error) {
+	subjectTemplate, err := template.New("subject").Parse(subject)
+	if err != nil {
+		return nil, err
+	}
+	bodyTemplate, err := template.New("body").Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{
+		Subject: subjectTemplate,
+		Body:    bodyTemplate,
+	}, nil
+}
+
+// MustTemplate is like NewTemplate but panics if the templates can't be parsed
+func MustTemplate(subject string, body string) *Template {
+	tmpl, err := NewTemplate(subject, body)
+	if err != nil {
+		panic(err.Error())
+	}
+	return tmpl
+}
+
+// DefaultTemplate is used by routes without a template. It handles every event type
+var DefaultTemplate = MustTemplate(
+	`{{if .Report}}Report #{{.Report.ID}}{{with .Report.Title}} "{{.}}"{{end}}: {{end}}{{.Type}}`,
+	`{{if .Err}}{{.Err}}{{end}}`+
+		`{{if .Field}}{{.Field}} changed from {{.Before}} to {{.After}}{{else if .State}}State changed to {{.State}}{{end}}`+
+		`{{if .Activity}}{{with .Activity.Message}}{{if .}}{{.}}{{end}}{{end}}{{end}}`+
+		`{{if .URL}}`+"\n"+`{{.URL}}{{end}}`,
+)
+
+// Format executes the templates against an event
+func (t *Template) Format(event polling.Event) (Message, error) {
+	data := TemplateData{Event: event}
+	if event.Report != nil && event.Report.ID != nil {
+		data.URL = reportURLPrefix + *event.Report.ID
+	}
+
+	var subject, body bytes.Buffer
+	if err := t.Subject.Execute(&subject, data); err != nil {
+		return Message{}, err
+	}
+	if err := t.Body.Execute(&body, data); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		Event:   event,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()),
+	}, nil
+}
+
+// Route sends events of some types to a sink
+type Route struct {
+	EventTypes []string  // The event types to send, empty sends every type
+	Template   *Template // How to format the events, defaults to DefaultTemplate
+	Sink       Sink      // Where to send the events
+}
+
+// Matches returns whether the route sends an event
+func (r Route) Matches(event polling.Event) bool {
+	if len(r.EventTypes) == 0 {
+		return true
+	}
+	for _, eventType := range r.EventTypes {
+		if eventType == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// Router is a polling.Dispatcher which sends each event to the sink of every matching route
+type Router struct {
+	Routes  []Route
+	OnError func(err error) // Called when an event can't be formatted or sent, errors are dropped if nil
+}
+
+// Dispatch sends an event to the sink of every matching route, in order
+func (r *Router) Dispatch(event polling.Event) {
+	for _, route := range r.Routes {
+		if !route.Matches(event) {
+			continue
+		}
+		tmpl := route.Template
+		if tmpl == nil {
+			tmpl = DefaultTemplate
+		}
+		message, err := tmpl.Format(event)
+		if err == nil {
+			err = route.Sink.Send(message)
+		}
+		if err != nil && r.OnError != nil {
+			r.OnError(err)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package notify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/polling"
+
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// recordingSink records the messages sent to it
+type recordingSink struct {
+	messages []Message
+	err      error
+}
+
+func (s *recordingSink) Send(message Message) error {
+	s.messages = append(s.messages, message)
+	return s.err
+}
+
+func newTestActivity(t *testing.T, activityType string, attributes string) *h1.Activity {
+	var activity h1.Activity
+	data := `{"id":"1338","type":"` + activityType + `","attributes":` + attributes + `}`
+	require.Nil(t, json.Unmarshal([]byte(data), &activity))
+	return &activity
+}
+
+func Test_DefaultTemplate(t *testing.T) {
+	report := &h1.Report{ID: h1.String("1337"), Title: h1.String("XSS in login form")}
+	tests := []struct {
+		event   polling.Event
+		subject string
+		body    string
+	}{
+		{
+			polling.Event{Type: polling.EventReportCreated, Report: report},
+			`Report #1337 "XSS in login form": report-created`,
+			"https://hackerone.com/reports/1337",
+		},
+		{
+			polling.NewActivityEvent(report, newTestActivity(t, h1.ActivityCommentType, `{"message":"Thanks!"}`)),
+			`Report #1337 "XSS in login form": comment-added`,
+			"Thanks!\nhttps://hackerone.com/reports/1337",
+		},
+		{
+			polling.NewActivityEvent(report, newTestActivity(t, h1.ActivityBugTriagedType, `{}`)),
+			`Report #1337 "XSS in login form": state-changed`,
+			"State changed to triaged\nhttps://hackerone.com/reports/1337",
+		},
+		{
+			polling.Event{Type: polling.EventReportChanged, Report: report, Field: polling.ReportFieldSeverity, Before: "low", After: "high"},
+			`Report #1337 "XSS in login form": report-changed`,
+			"severity changed from low to high\nhttps://hackerone.com/reports/1337",
+		},
+		{
+			polling.Event{Type: polling.EventError, Err: errors.New("oops")},
+			"error",
+			"oops",
+		},
+	}
+	for _, test := range tests {
+		message, err := DefaultTemplate.Format(test.event)
+		assert.Nil(t, err)
+		assert.Equal(t, test.subject, message.Subject)
+		assert.Equal(t, test.body, message.Body)
+		assert.Equal(t, test.event, message.Event)
+	}
+}
+
+func Test_NewTemplate(t *testing.T) {
+	// Verify that fields are dereferenced
+	tmpl, err := NewTemplate("{{.Report.Title}}", "{{.Report.ID}} {{.URL}}")
+	require.Nil(t, err)
+	message, err := tmpl.Format(polling.Event{Report: &h1.Report{ID: h1.String("1337"), Title: h1.String("XSS")}})
+	assert.Nil(t, err)
+	assert.Equal(t, "XSS", message.Subject)
+	assert.Equal(t, "1337 https://hackerone.com/reports/1337", message.Body)
+
+	// Verify that execution errors are returned
+	_, err = tmpl.Format(polling.Event{})
+	assert.NotNil(t, err)
+	tmpl, err = NewTemplate("", "{{.Report.Title}}")
+	require.Nil(t, err)
+	_, err = tmpl.Format(polling.Event{})
+	assert.NotNil(t, err)
+
+	// Verify that parse errors are returned
+	_, err = NewTemplate("{{", "")
+	assert.NotNil(t, err)
+	_, err = NewTemplate("", "{{")
+	assert.NotNil(t, err)
+	assert.Panics(t, func() {
+		MustTemplate("{{", "")
+	})
+}
+
+func Test_Router(t *testing.T) {
+	all := &recordingSink{}
+	bounties := &recordingSink{}
+	failing := &recordingSink{err: errors.New("unavailable")}
+	var errs []error
+	router := Router{
+		Routes: []Route{
+			Route{Sink: all},
+			Route{EventTypes: []string{polling.EventBountyAwarded}, Template: MustTemplate("Bounty!", ""), Sink: bounties},
+			Route{EventTypes: []string{polling.EventError}, Sink: failing},
+			Route{EventTypes: []string{polling.EventReportCreated}, Template: MustTemplate("{{.Activity.Message}}", ""), Sink: bounties},
+		},
+		OnError: func(err error) {
+			errs = append(errs, err)
+		},
+	}
+
+	report := &h1.Report{ID: h1.String("1337")}
+	router.Dispatch(polling.NewActivityEvent(report, newTestActivity(t, h1.ActivityBountyAwardedType, `{"bounty_amount":"500"}`)))
+	router.Dispatch(polling.Event{Type: polling.EventError, Err: errors.New("oops")})
+	router.Dispatch(polling.Event{Type: polling.EventReportCreated, Report: report})
+
+	// Verify that every route received its events
+	assert.Len(t, all.messages, 3)
+	require.Len(t, bounties.messages, 1)
+	assert.Equal(t, "Bounty!", bounties.messages[0].Subject)
+	assert.Len(t, failing.messages, 1)
+
+	// Verify that send and format errors were reported
+	require.Len(t, errs, 2)
+	assert.Equal(t, "unavailable", errs[0].Error())
+	assert.Contains(t, errs[1].Error(), "nil pointer")
+}
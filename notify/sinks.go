@@ -0,0 +1,123 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// WebhookSink posts messages as JSON to a URL
+type WebhookSink struct {
+	URL    string
+	Client *http.Client // The client to post with, defaults to http.DefaultClient
+}
+
+// webhookPayload is the JSON posted by a WebhookSink
+type webhookPayload struct {
+	EventType  string `json:"event_type"`
+	Subject    string `json:"subject"`
+	Body       string `json:"body"`
+	ReportID   string `json:"report_id,omitempty"`
+	ActivityID string `json:"activity_id,omitempty"`
+	Field      string `json:"field,omitempty"`
+	Before     string `json:"before,omitempty"`
+	After      string `json:"after,omitempty"`
+}
+
+// Send posts the message
+func (s *WebhookSink) Send(message Message) error {
+	payload := webhookPayload{
+		EventType: message.Event.Type,
+		Subject:   message.Subject,
+		Body:      message.Body,
+		Field:     message.Event.Field,
+		Before:    message.Event.Before,
+		After:     message.Event.After,
+	}
+	if message.Event.Report != nil && message.Event.Report.ID != nil {
+		payload.ReportID = *message.Event.Report.ID
+	}
+	if message.Event.Activity != nil && message.Event.Activity.ID != nil {
+		payload.ActivityID = *message.Event.Activity.ID
+	}
+	return postJSON(s.Client, s.URL, payload)
+}
+
+// SlackSink posts messages to a Slack-compatible incoming webhook
+type SlackSink struct {
+	URL       string
+	Client    *http.Client // The client to post with, defaults to http.DefaultClient
+	Channel   string       // Overrides the webhook's channel if set
+	Username  string       // Overrides the webhook's username if set
+	IconEmoji string       // Overrides the webhook's icon if set, e.g. ":bug:"
+}
+
+// slackPayload is the JSON posted by a SlackSink
+type slackPayload struct {
+	Text      string `json:"text"`
+	Channel   string `json:"channel,omitempty"`
+	Username  string `json:"username,omitempty"`
+	IconEmoji string `json:"icon_emoji,omitempty"`
+}
+
+// slackEscaper escapes the characters Slack treats as control characters, so reports can't inject mentions or links
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Send posts the message, with the subject in bold
+func (s *SlackSink) Send(message Message) error {
+	text := "*" + slackEscaper.Replace(message.Subject) + "*"
+	if message.Body != "" {
+		text += "\n" + slackEscaper.Replace(message.Body)
+	}
+	return postJSON(s.Client, s.URL, slackPayload{
+		Text:      text,
+		Channel:   s.Channel,
+		Username:  s.Username,
+		IconEmoji: s.IconEmoji,
+	})
+}
+
+// postJSON posts a value as JSON, returning an error unless the response is successful
+func postJSON(client *http.Client, url string, v interface{}) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notify: %s returned %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package notify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/polling"
+
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newJSONServer records the JSON bodies posted to it and responds with status
+func newJSONServer(t *testing.T, status int, bodies *[]map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var body map[string]string
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		*bodies = append(*bodies, body)
+		w.WriteHeader(status)
+	}))
+}
+
+func Test_WebhookSink_Send(t *testing.T) {
+	var bodies []map[string]string
+	server := newJSONServer(t, http.StatusOK, &bodies)
+	defer server.Close()
+
+	sink := WebhookSink{URL: server.URL}
+	err := sink.Send(Message{
+		Event: polling.Event{
+			Type:     polling.EventCommentAdded,
+			Report:   &h1.Report{ID: h1.String("1337")},
+			Activity: &h1.Activity{ID: h1.String("1338")},
+		},
+		Subject: "Subject",
+		Body:    "Body",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]string{
+		map[string]string{
+			"event_type":  polling.EventCommentAdded,
+			"subject":     "Subject",
+			"body":        "Body",
+			"report_id":   "1337",
+			"activity_id": "1338",
+		},
+	}, bodies)
+
+	// Verify that unsuccessful responses fail
+	errorServer := newJSONServer(t, http.StatusInternalServerError, &bodies)
+	defer errorServer.Close()
+	sink.URL = errorServer.URL
+	assert.NotNil(t, sink.Send(Message{}))
+
+	// Verify that unreachable servers fail
+	sink.URL = "http://[fe80::1%en0]/"
+	assert.NotNil(t, sink.Send(Message{}))
+}
+
+func Test_SlackSink_Send(t *testing.T) {
+	var bodies []map[string]string
+	server := newJSONServer(t, http.StatusOK, &bodies)
+	defer server.Close()
+
+	sink := SlackSink{URL: server.URL, Channel: "#security", Username: "hackeroni"}
+	assert.Nil(t, sink.Send(Message{Subject: "Subject", Body: "Body"}))
+	assert.Nil(t, sink.Send(Message{Subject: "Subject"}))
+	assert.Equal(t, []map[string]string{
+		map[string]string{
+			"text":     "*Subject*\nBody",
+			"channel":  "#security",
+			"username": "hackeroni",
+		},
+		map[string]string{
+			"text":     "*Subject*",
+			"channel":  "#security",
+			"username": "hackeroni",
+		},
+	}, bodies)
+
+	// Verify that control characters are escaped so reports can't mention or link
+	bodies = nil
+	assert.Nil(t, sink.Send(Message{Subject: "<!channel> & co", Body: "See <https://example.com|here> &amp;"}))
+	require.Len(t, bodies, 1)
+	assert.Equal(t, "*&lt;!channel&gt; &amp; co*\nSee &lt;https://example.com|here&gt; &amp;amp;", bodies[0]["text"])
+
+	// Verify that unsuccessful responses fail
+	errorServer := newJSONServer(t, http.StatusNotFound, &bodies)
+	defer errorServer.Close()
+	sink.URL = errorServer.URL
+	assert.NotNil(t, sink.Send(Message{}))
+}
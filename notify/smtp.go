@@ -0,0 +1,56 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPSink emails messages
+type SMTPSink struct {
+	Addr string    // The address of the SMTP server, e.g. "smtp.example.com:587"
+	Auth smtp.Auth // How to authenticate, nil to not authenticate
+	From string
+	To   []string
+}
+
+// Send emails the message. The subject becomes the email's subject and the body is sent as plain text
+func (s *SMTPSink) Send(message Message) error {
+	return smtp.SendMail(s.Addr, s.Auth, s.From, s.To, s.email(message))
+}
+
+// email returns the message as an email
+func (s *SMTPSink) email(message Message) []byte {
+	var email bytes.Buffer
+	fmt.Fprintf(&email, "From: %s\r\n", s.From)
+	fmt.Fprintf(&email, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&email, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&email, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&email, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&email, "\r\n")
+	email.WriteString(strings.Replace(message.Body, "\n", "\r\n", -1))
+	email.WriteString("\r\n")
+	return email.Bytes()
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package notify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bufio"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// fakeSMTPMail is a mail received by a fake SMTP server
+type fakeSMTPMail struct {
+	From string
+	To   []string
+	Data string
+}
+
+// newFakeSMTPServer accepts a single SMTP session and sends the mail it receives, or rejects recipients if reject is set
+func newFakeSMTPServer(t *testing.T, reject bool) (string, chan fakeSMTPMail) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	mails := make(chan fakeSMTPMail, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		text := textproto.NewConn(conn)
+		text.PrintfLine("220 localhost ESMTP")
+
+		var mail fakeSMTPMail
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch command {
+			case "EHLO", "HELO":
+				text.PrintfLine("250 localhost")
+			case "MAIL":
+				mail.From = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+				text.PrintfLine("250 OK")
+			case "RCPT":
+				if reject {
+					text.PrintfLine("550 No such user")
+					continue
+				}
+				mail.To = append(mail.To, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+				text.PrintfLine("250 OK")
+			case "DATA":
+				text.PrintfLine("354 Go ahead")
+				data, err := text.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				mail.Data = string(data)
+				text.PrintfLine("250 OK")
+				mails <- mail
+			case "QUIT":
+				text.PrintfLine("221 Bye")
+				return
+			default:
+				text.PrintfLine("502 Not implemented")
+			}
+		}
+	}()
+	return listener.Addr().String(), mails
+}
+
+func Test_SMTPSink_Send(t *testing.T) {
+	addr, mails := newFakeSMTPServer(t, false)
+	sink := SMTPSink{
+		Addr: addr,
+		From: "hackeroni@example.com",
+		To:   []string{"security@example.com", "oncall@example.com"},
+	}
+	assert.Nil(t, sink.Send(Message{Subject: "Report #1337: comment-added", Body: "Thanks!\nhttps://hackerone.com/reports/1337"}))
+
+	mail := <-mails
+	assert.Equal(t, "hackeroni@example.com", mail.From)
+	assert.Equal(t, []string{"security@example.com", "oncall@example.com"}, mail.To)
+	headers, err := textproto.NewReader(bufio.NewReader(strings.NewReader(mail.Data))).ReadMIMEHeader()
+	require.Nil(t, err)
+	assert.Equal(t, "hackeroni@example.com", headers.Get("From"))
+	assert.Equal(t, "security@example.com, oncall@example.com", headers.Get("To"))
+	assert.Equal(t, "Report #1337: comment-added", headers.Get("Subject"))
+	assert.Equal(t, "text/plain; charset=utf-8", headers.Get("Content-Type"))
+	assert.True(t, strings.HasSuffix(mail.Data, "\n\nThanks!\nhttps://hackerone.com/reports/1337\n"))
+}
+
+func Test_SMTPSink_Send_rejected(t *testing.T) {
+	addr, _ := newFakeSMTPServer(t, true)
+	sink := SMTPSink{
+		Addr: addr,
+		From: "hackeroni@example.com",
+		To:   []string{"nobody@example.com"},
+	}
+	assert.NotNil(t, sink.Send(Message{Subject: "Subject"}))
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package polling
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/metrics"
+
+	"sync"
+)
+
+// EventType represent the possible types of an Event
+const (
+	EventError           string = "error"            // Polling failed, see Err
+	EventReportCreated   string = "report-created"   // A new report was submitted
+	EventStateChanged    string = "state-changed"    // The report's state changed, see State
+	EventCommentAdded    string = "comment-added"    // A comment was added to the report
+	EventBountyAwarded   string = "bounty-awarded"   // A bounty was awarded on the report
+	EventAssigneeChanged string = "assignee-changed" // The report was assigned to a user or group
+	EventActivity        string = "activity"         // Any other activity on the report
+	EventReportChanged   string = "report-changed"   // A field of the report changed since it was last polled, see Field, Before and After
+)
+
+// ReportField represent the fields of a report which are tracked for report-changed events
+const (
+	ReportFieldState    string = "state"    // The report's state
+	ReportFieldSeverity string = "severity" // The report's severity rating, or metrics.Unrated
+	ReportFieldAssignee string = "assignee" // The assigned user's username or group's name, or metrics.Unassigned
+)
+
+// Event is something which happened on a report
+type Event struct {
+	Type     string
+	Report   *h1.Report   // The report the event happened on, nil for errors
+	Activity *h1.Activity // The activity which caused the event, nil for new reports and errors. Report-changed events have the matching activity if there was one
+	State    string       // The new state of the report for state-changed events
+	Field    string       // The field which changed for report-changed events
+	Before   string       // The previous value of the field for report-changed events
+	After    string       // The new value of the field for report-changed events
+	Err      error        // The error for error events
+}
+
+// activityStates maps the activities which change a report's state to the state they change it to
+var activityStates = map[string]string{
+	h1.ActivityBugNewType:           h1.ReportStateNew,
+	h1.ActivityBugTriagedType:       h1.ReportStateTriaged,
+	h1.ActivityBugNeedsMoreInfoType: h1.ReportStateNeedsMoreInfo,
+	h1.ActivityBugResolvedType:      h1.ReportStateResolved,
+	h1.ActivityBugNotApplicableType: h1.ReportStateNotApplicable,
+	h1.ActivityBugInformativeType:   h1.ReportStateInformative,
+	h1.ActivityBugDuplicateType:     h1.ReportStateDuplicate,
+	h1.ActivityBugSpamType:          h1.ReportStateSpam,
+	h1.ActivityBugReopenedType:      "", // The state it was reopened to isn't part of the activity
+}
+
+// activityFields maps the activities which change tracked fields of a report to the field they change
+var activityFields = map[string]string{
+	h1.ActivityReportSeverityUpdatedType: ReportFieldSeverity,
+	h1.ActivityUserAssignedToBugType:     ReportFieldAssignee,
+	h1.ActivityGroupAssignedToBugType:    ReportFieldAssignee,
+}
+
+// activityField returns the tracked field an activity changes, if any
+func activityField(activity *h1.Activity) (string, bool) {
+	if activity.Type == nil {
+		return "", false
+	}
+	if _, ok := activityStates[*activity.Type]; ok {
+		return ReportFieldState, true
+	}
+	field, ok := activityFields[*activity.Type]
+	return field, ok
+}
+
+// newReportSnapshot returns the tracked fields of a report
+func newReportSnapshot(report *h1.Report) (snapshot ReportSnapshot) {
+	if report.State != nil {
+		snapshot.State = *report.State
+	}
+	snapshot.Severity = metrics.SeverityRating(report)
+	snapshot.Assignee = metrics.AssigneeName(report)
+	if report.LastActivityAt != nil {
+		snapshot.LastActivityAt = report.LastActivityAt.Time
+	}
+	return snapshot
+}
+
+// newChangeEvents returns a report-changed event for each tracked field which differs between the snapshots, along with the latest activity which changed it
+func newChangeEvents(report *h1.Report, before ReportSnapshot, after ReportSnapshot, activities map[string]*h1.Activity) (events []Event) {
+	fields := []struct {
+		Name   string
+		Before string
+		After  string
+	}{
+		{ReportFieldState, before.State, after.State},
+		{ReportFieldSeverity, before.Severity, after.Severity},
+		{ReportFieldAssignee, before.Assignee, after.Assignee},
+	}
+	for _, field := range fields {
+		if field.Before == field.After {
+			continue
+		}
+		events = append(events, Event{
+			Type:     EventReportChanged,
+			Report:   report,
+			Activity: activities[field.Name],
+			Field:    field.Name,
+			Before:   field.Before,
+			After:    field.After,
+		})
+	}
+	return events
+}
+
+// NewActivityEvent returns the event for an activity on a report
+func NewActivityEvent(report *h1.Report, activity *h1.Activity) Event {
+	event := Event{
+		Type:     EventActivity,
+		Report:   report,
+		Activity: activity,
+	}
+	if activity.Type == nil {
+		return event
+	}
+	if state, ok := activityStates[*activity.Type]; ok {
+		event.Type = EventStateChanged
+		event.State = state
+		// Reopened reports take whatever state they're in now
+		if state == "" && report.State != nil {
+			event.State = *report.State
+		}
+		return event
+	}
+	switch *activity.Type {
+	case h1.ActivityCommentType:
+		event.Type = EventCommentAdded
+	case h1.ActivityBountyAwardedType:
+		event.Type = EventBountyAwarded
+	case h1.ActivityUserAssignedToBugType, h1.ActivityGroupAssignedToBugType:
+		event.Type = EventAssigneeChanged
+	}
+	return event
+}
+
+// Dispatcher is anything which events can be dispatched to, such as Handlers
+type Dispatcher interface {
+	Dispatch(event Event)
+}
+
+// Handlers dispatches events to the handlers registered for their type. The zero value is ready to use
+type Handlers struct {
+	mu       sync.Mutex
+	handlers map[string][]func(Event)
+}
+
+// On registers a handler for every event of a type. Handlers are called in the order they were registered
+func (h *Handlers) On(eventType string, handler func(Event)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.handlers == nil {
+		h.handlers = make(map[string][]func(Event))
+	}
+	h.handlers[eventType] = append(h.handlers[eventType], handler)
+}
+
+// OnError registers a handler for polling errors
+func (h *Handlers) OnError(handler func(err error)) {
+	h.On(EventError, func(event Event) {
+		handler(event.Err)
+	})
+}
+
+// OnReportCreated registers a handler for new reports
+func (h *Handlers) OnReportCreated(handler func(report *h1.Report)) {
+	h.On(EventReportCreated, func(event Event) {
+		handler(event.Report)
+	})
+}
+
+// OnStateChanged registers a handler for reports changing state
+func (h *Handlers) OnStateChanged(handler func(report *h1.Report, activity *h1.Activity, state string)) {
+	h.On(EventStateChanged, func(event Event) {
+		handler(event.Report, event.Activity, event.State)
+	})
+}
+
+// OnCommentAdded registers a handler for comments added to reports
+func (h *Handlers) OnCommentAdded(handler func(report *h1.Report, activity *h1.Activity)) {
+	h.On(EventCommentAdded, func(event Event) {
+		handler(event.Report, event.Activity)
+	})
+}
+
+// OnBountyAwarded registers a handler for bounties awarded on reports
+func (h *Handlers) OnBountyAwarded(handler func(report *h1.Report, activity *h1.Activity, bounty *h1.ActivityBountyAwarded)) {
+	h.On(EventBountyAwarded, func(event Event) {
+		bounty, _ := event.Activity.Activity().(*h1.ActivityBountyAwarded)
+		handler(event.Report, event.Activity, bounty)
+	})
+}
+
+// OnAssigneeChanged registers a handler for reports being assigned. The assignee is the report's Assignee()
+func (h *Handlers) OnAssigneeChanged(handler func(report *h1.Report, activity *h1.Activity)) {
+	h.On(EventAssigneeChanged, func(event Event) {
+		handler(event.Report, event.Activity)
+	})
+}
+
+// OnReportChanged registers a handler for changes to a report's state, severity or assignee. The activity is nil if no matching activity was found
+func (h *Handlers) OnReportChanged(handler func(report *h1.Report, activity *h1.Activity, field string, before string, after string)) {
+	h.On(EventReportChanged, func(event Event) {
+		handler(event.Report, event.Activity, event.Field, event.Before, event.After)
+	})
+}
+
+// OnActivity registers a handler for activities which don't have a more specific event type
+func (h *Handlers) OnActivity(handler func(report *h1.Report, activity *h1.Activity)) {
+	h.On(EventActivity, func(event Event) {
+		handler(event.Report, event.Activity)
+	})
+}
+
+// Dispatch calls the handlers registered for the event's type
+func (h *Handlers) Dispatch(event Event) {
+	h.mu.Lock()
+	handlers := h.handlers[event.Type]
+	h.mu.Unlock()
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// Serve dispatches every event from a channel, such as a Poller's Events, until it is closed
+func (h *Handlers) Serve(events <-chan Event) {
+	for event := range events {
+		h.Dispatch(event)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package polling
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func newTestActivity(t *testing.T, activityType string, attributes string) *h1.Activity {
+	var activity h1.Activity
+	data := `{"id":"1","type":"` + activityType + `","attributes":` + attributes + `}`
+	require.Nil(t, json.Unmarshal([]byte(data), &activity))
+	return &activity
+}
+
+func Test_NewActivityEvent(t *testing.T) {
+	report := &h1.Report{ID: h1.String("1337"), State: h1.String(h1.ReportStateTriaged)}
+	tests := []struct {
+		activityType string
+		eventType    string
+		state        string
+	}{
+		{h1.ActivityBugResolvedType, EventStateChanged, h1.ReportStateResolved},
+		{h1.ActivityBugReopenedType, EventStateChanged, h1.ReportStateTriaged},
+		{h1.ActivityCommentType, EventCommentAdded, ""},
+		{h1.ActivityBountyAwardedType, EventBountyAwarded, ""},
+		{h1.ActivityUserAssignedToBugType, EventAssigneeChanged, ""},
+		{h1.ActivityGroupAssignedToBugType, EventAssigneeChanged, ""},
+		{h1.ActivitySwagAwardedType, EventActivity, ""},
+	}
+	for _, test := range tests {
+		activity := newTestActivity(t, test.activityType, `{}`)
+		event := NewActivityEvent(report, activity)
+		assert.Equal(t, test.eventType, event.Type, test.activityType)
+		assert.Equal(t, test.state, event.State, test.activityType)
+		assert.Equal(t, report, event.Report)
+		assert.Equal(t, activity, event.Activity)
+	}
+}
+
+func Test_Handlers(t *testing.T) {
+	var handlers Handlers
+	var calls []string
+	handlers.OnError(func(err error) {
+		calls = append(calls, "error "+err.Error())
+	})
+	handlers.OnReportCreated(func(report *h1.Report) {
+		calls = append(calls, "report "+*report.ID)
+	})
+	handlers.OnStateChanged(func(report *h1.Report, activity *h1.Activity, state string) {
+		calls = append(calls, "state "+state)
+	})
+	handlers.OnCommentAdded(func(report *h1.Report, activity *h1.Activity) {
+		calls = append(calls, "comment "+*activity.Message)
+	})
+	handlers.OnBountyAwarded(func(report *h1.Report, activity *h1.Activity, bounty *h1.ActivityBountyAwarded) {
+		calls = append(calls, "bounty "+*bounty.BountyAmount)
+	})
+	handlers.OnAssigneeChanged(func(report *h1.Report, activity *h1.Activity) {
+		calls = append(calls, "assignee")
+	})
+	handlers.OnActivity(func(report *h1.Report, activity *h1.Activity) {
+		calls = append(calls, "activity "+*activity.Type)
+	})
+	handlers.OnReportChanged(func(report *h1.Report, activity *h1.Activity, field string, before string, after string) {
+		calls = append(calls, field+" "+before+" > "+after)
+	})
+	handlers.On(EventReportCreated, func(event Event) {
+		calls = append(calls, "second report handler")
+	})
+
+	report := &h1.Report{ID: h1.String("1337")}
+	events := make(chan Event, 10)
+	events <- Event{Type: EventError, Err: errors.New("oops")}
+	events <- Event{Type: EventReportCreated, Report: report}
+	events <- NewActivityEvent(report, newTestActivity(t, h1.ActivityBugTriagedType, `{}`))
+	events <- NewActivityEvent(report, newTestActivity(t, h1.ActivityCommentType, `{"message":"Hi"}`))
+	events <- NewActivityEvent(report, newTestActivity(t, h1.ActivityBountyAwardedType, `{"bounty_amount":"500"}`))
+	events <- NewActivityEvent(report, newTestActivity(t, h1.ActivityUserAssignedToBugType, `{}`))
+	events <- NewActivityEvent(report, newTestActivity(t, h1.ActivitySwagAwardedType, `{}`))
+	events <- Event{Type: EventReportChanged, Report: report, Field: ReportFieldState, Before: h1.ReportStateNew, After: h1.ReportStateTriaged}
+	close(events)
+	handlers.Serve(events)
+
+	assert.Equal(t, []string{
+		"error oops",
+		"report 1337",
+		"second report handler",
+		"state triaged",
+		"comment Hi",
+		"bounty 500",
+		"assignee",
+		"activity activity-swag-awarded",
+		"state new > triaged",
+	}, calls)
+}
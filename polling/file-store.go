@@ -0,0 +1,166 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package polling
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileStoreData is the contents of a FileStore's file
+type fileStoreData struct {
+	Reports    map[string]time.Time      `json:"reports"`
+	Activities map[string]time.Time      `json:"activities"`
+	Snapshots  map[string]ReportSnapshot `json:"snapshots"`
+	LastPoll   time.Time                 `json:"last_poll"`
+}
+
+// FileStore is a Store which persists to a JSON file when flushed. Changes made since the last flush are lost if the process exits
+type FileStore struct {
+	path   string
+	memory *MemoryStore
+	dirty  bool // Guarded by the memory store's lock
+}
+
+// NewFileStore opens a FileStore at path, loading anything previously flushed to it. The file is created on the first flush
+func NewFileStore(path string) (*FileStore, error) {
+	memory := NewMemoryStore()
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		var contents fileStoreData
+		if err := json.Unmarshal(data, &contents); err != nil {
+			return nil, err
+		}
+		for id, lastActivityAt := range contents.Reports {
+			memory.reports[id] = lastActivityAt
+		}
+		for id, updatedAt := range contents.Activities {
+			memory.activities[id] = updatedAt
+		}
+		for id, snapshot := range contents.Snapshots {
+			memory.snapshots[id] = snapshot
+		}
+		memory.lastPoll = contents.LastPoll
+	}
+	store := &FileStore{
+		path:   path,
+		memory: memory,
+	}
+	// The memory store's lock guards dirty, so a change can't be missed by a concurrent flush
+	memory.onChange = func() {
+		store.dirty = true
+	}
+	return store, nil
+}
+
+// LastActivity returns the last activity time recorded for a report
+func (s *FileStore) LastActivity(reportID string) (time.Time, bool, error) {
+	return s.memory.LastActivity(reportID)
+}
+
+// SetLastActivity records the last activity time of a report
+func (s *FileStore) SetLastActivity(reportID string, lastActivityAt time.Time) error {
+	return s.memory.SetLastActivity(reportID, lastActivityAt)
+}
+
+// ActivitySeen returns the updated time recorded for an emitted activity
+func (s *FileStore) ActivitySeen(activityID string) (time.Time, bool, error) {
+	return s.memory.ActivitySeen(activityID)
+}
+
+// SetActivitySeen records that an activity was emitted along with its updated time
+func (s *FileStore) SetActivitySeen(activityID string, updatedAt time.Time) error {
+	return s.memory.SetActivitySeen(activityID, updatedAt)
+}
+
+// Snapshot returns the fields recorded for a report
+func (s *FileStore) Snapshot(reportID string) (ReportSnapshot, bool, error) {
+	return s.memory.Snapshot(reportID)
+}
+
+// SetSnapshot records the fields of a report
+func (s *FileStore) SetSnapshot(reportID string, snapshot ReportSnapshot) error {
+	return s.memory.SetSnapshot(reportID, snapshot)
+}
+
+// LastPoll returns when the last successful poll started
+func (s *FileStore) LastPoll() (time.Time, bool, error) {
+	return s.memory.LastPoll()
+}
+
+// SetLastPoll records when the last successful poll started
+func (s *FileStore) SetLastPoll(startedAt time.Time) error {
+	return s.memory.SetLastPoll(startedAt)
+}
+
+// Expire forgets reports last active and activities updated before a time. Snapshots are only forgotten for closed reports
+func (s *FileStore) Expire(before time.Time) error {
+	return s.memory.Expire(before)
+}
+
+// Flush writes the store to its file if it changed. The file is replaced atomically so a crash never leaves it partially written
+func (s *FileStore) Flush() error {
+	s.memory.mu.Lock()
+	defer s.memory.mu.Unlock()
+	if !s.dirty {
+		return nil
+	}
+
+	data, err := json.Marshal(fileStoreData{
+		Reports:    s.memory.reports,
+		Activities: s.memory.activities,
+		Snapshots:  s.memory.snapshots,
+		LastPoll:   s.memory.lastPoll,
+	})
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	s.dirty = false
+	return nil
+}
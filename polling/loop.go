@@ -0,0 +1,135 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package polling
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Loop runs a function at an interval until its context is done or it is stopped. Poller and SLAWatcher use it, and it can be used to give
+// other periodic work the same Run and Stop behaviour. A Loop can only be run once
+type Loop struct {
+	name    string // Used in errors, e.g. "poller"
+	mu      sync.Mutex
+	started bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewLoop creates a Loop, named in the errors it returns
+func NewLoop(name string) *Loop {
+	return &Loop{
+		name: name,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Run calls tick immediately and then at the interval until the context is done or Stop is called. The context passed to tick is also cancelled by Stop.
+// Once it is finished, it calls exit if it's set before returning the context's error if the context ended the loop, and nil if Stop did.
+func (l *Loop) Run(ctx context.Context, interval time.Duration, tick func(context.Context), exit func()) error {
+	l.mu.Lock()
+	if l.started {
+		l.mu.Unlock()
+		return errors.New(l.name + " has already been run")
+	}
+	l.started = true
+	l.mu.Unlock()
+
+	defer close(l.done)
+	if exit != nil {
+		defer exit()
+	}
+
+	// If we were stopped before running, don't tick at all
+	select {
+	case <-l.stop:
+		return nil
+	default:
+	}
+	if interval <= 0 {
+		return errors.New(l.name + " interval must be positive")
+	}
+
+	// Stop cancels the context so a single check covers both
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-l.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		tick(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			select {
+			case <-l.stop:
+				return nil
+			default:
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// Stop stops the loop and waits for Run to return. It is safe to call more than once
+func (l *Loop) Stop() {
+	l.mu.Lock()
+	select {
+	case <-l.stop:
+	default:
+		close(l.stop)
+	}
+	started := l.started
+	l.mu.Unlock()
+	if started {
+		<-l.done
+	}
+}
+
+// ListReports returns the reports matching the filter from every page
+func ListReports(client *h1.Client, filter h1.ReportListFilter) ([]h1.Report, error) {
+	var allReports []h1.Report
+	var listOptions h1.ListOptions
+	for {
+		reports, resp, err := client.Report.List(filter, &listOptions)
+		if err != nil {
+			return nil, err
+		}
+		allReports = append(allReports, reports...)
+		if resp.Links.Next == "" {
+			return allReports, nil
+		}
+		listOptions.Page = resp.Links.NextPageNumber()
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package polling
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+
+	"context"
+	"sync"
+	"time"
+)
+
+// Poller polls for new reports and activities until it is stopped, emitting them as Events
+type Poller struct {
+	Client      *h1.Client          // The h1.Client to use when making requests
+	Filter      h1.ReportListFilter // The h1.ReportListOptions to use when making requests
+	Interval    time.Duration       // How often to poll
+	Window      time.Duration       // How long to look back, recommended 2*Interval. If the Store's last successful poll is older, polling looks back to it instead
+	ClockSkew   time.Duration       // How far HackerOne's clock may be behind ours, added to the Window. Defaults to a minute
+	Store       Store               // Where to keep track of what we've seen, defaults to a MemoryStore
+	Concurrency int                 // How many full reports to fetch at once, defaults to 4. Reports and activities are still emitted in order
+	OnPoll      func(PollStats)     // Called with the stats of each poll once it finishes, for example to export them as metrics
+
+	eventChan chan Event
+	loop      *Loop
+
+	mu       sync.Mutex // Guards lastPoll
+	lastPoll PollStats
+}
+
+// PollStats describes a finished poll
+type PollStats struct {
+	StartedAt  time.Time
+	Duration   time.Duration // How long the poll took, including waiting for emitted objects to be read
+	Reports    int           // How many reports were listed
+	Fetched    int           // How many full reports were fetched
+	NewReports int           // How many new reports were emitted
+	Activities int           // How many activities were emitted
+	Changes    int           // How many report-changed events were emitted
+	Errors     int           // How many errors occurred
+}
+
+// NewPoller creates a Poller using a MemoryStore. Its channel holds up to bufferSize events before the poller waits for them to be read.
+// Set Store before running the poller to resume from a previous run.
+func NewPoller(client *h1.Client, filter h1.ReportListFilter, interval time.Duration, window time.Duration, bufferSize int) *Poller {
+	return &Poller{
+		Client:      client,
+		Filter:      filter,
+		Interval:    interval,
+		Window:      window,
+		ClockSkew:   time.Minute,
+		Store:       NewMemoryStore(),
+		Concurrency: 4,
+		eventChan:   make(chan Event, bufferSize),
+		loop:        NewLoop("poller"),
+	}
+}
+
+// Events returns the channel events are emitted on. A new report is always emitted before its activities, which are emitted in order and followed by any changes to the report.
+// The channel is closed when the poller stops
+func (p *Poller) Events() <-chan Event {
+	return p.eventChan
+}
+
+// Run polls immediately and then at the interval until the context is done or Stop is called, then closes the events channel.
+// It returns the context's error if the context ended polling, and nil if Stop did. A Poller can only be run once.
+func (p *Poller) Run(ctx context.Context) error {
+	return p.loop.Run(ctx, p.Interval, func(ctx context.Context) {
+		p.update(ctx)
+		if err := p.Store.Flush(); err != nil {
+			p.emitError(ctx, err)
+		}
+	}, func() {
+		close(p.eventChan)
+	})
+}
+
+// Stop stops the poller and waits for Run to return. It is safe to call more than once
+func (p *Poller) Stop() {
+	p.loop.Stop()
+}
+
+// Perform a poll. It returns early if the context is done.
+//
+// Activities are emitted at most once per ID: overlapping windows and edits which change an activity's updated time don't emit it again.
+// Full reports are fetched concurrently, but reports and their activities are emitted in the order the reports were listed.
+func (p *Poller) update(ctx context.Context) {
+	stats := PollStats{StartedAt: time.Now().UTC()}
+	defer p.finishPoll(&stats)
+
+	// We want all reports updated since now minus the window, allowing for HackerOne's clock to be behind ours.
+	// If we were stopped for longer than the window, look back to the last successful poll so nothing updated in between is missed
+	updatedAt := stats.StartedAt.Add(-p.Window - p.ClockSkew)
+	lastPoll, polled, err := p.Store.LastPoll()
+	if err != nil {
+		p.emitError(ctx, err)
+		stats.Errors++
+		return
+	}
+	if polled && lastPoll.Add(-p.ClockSkew).Before(updatedAt) {
+		updatedAt = lastPoll.Add(-p.ClockSkew)
+	}
+
+	// Get the reports from every page
+	filter := p.Filter
+	filter.LastActivityAtGreaterThan = updatedAt
+	allReports, err := ListReports(p.Client, filter)
+	if err != nil {
+		p.emitError(ctx, err)
+		stats.Errors++
+		return
+	}
+	stats.Reports = len(allReports)
+
+	// Find the reports which changed since we last saw them
+	var changed []reportChange
+	for _, report := range allReports {
+		// Get the time we last saw that report
+		lastActivityAt, seen, err := p.Store.LastActivity(*report.ID)
+		if err != nil {
+			stats.Errors++
+			if !p.emitError(ctx, err) {
+				return
+			}
+			continue
+		}
+		// If we've seen it and the last activity updated time is equal, skip it
+		if seen && lastActivityAt.Equal(report.LastActivityAt.Time) {
+			continue
+		}
+		changed = append(changed, reportChange{
+			Report: report,
+			Seen:   seen,
+			Result: make(chan reportFetch, 1),
+		})
+	}
+
+	// In order to check the activities we have to pull the full reports, which the workers do in the background
+	p.fetch(ctx, changed)
+
+	// Loop each changed report in order as its fetch finishes
+	for _, change := range changed {
+		var fetch reportFetch
+		select {
+		case fetch = <-change.Result:
+		case <-ctx.Done():
+			return
+		}
+		if fetch.Err != nil {
+			stats.Errors++
+			if !p.emitError(ctx, fetch.Err) {
+				return
+			}
+			continue
+		}
+		stats.Fetched++
+		report := fetch.Report
+
+		// If we hadn't seen the report before, emit the event
+		if !change.Seen && report.CreatedAt.After(updatedAt) {
+			if !p.emit(ctx, Event{Type: EventReportCreated, Report: report}) {
+				return
+			}
+			stats.NewReports++
+		}
+
+		// Loop all activity in the report, keeping track of the latest activity which changed each tracked field
+		fieldActivities := make(map[string]*h1.Activity)
+		for idx := range report.Activities {
+			activity := &report.Activities[idx]
+			// If the activity was last updated before the time we updated at, ignore it
+			if activity.UpdatedAt.Time.Before(updatedAt) {
+				continue
+			}
+
+			// If we have seen the activity before, ignore it. If it was edited since, remember the new time so it doesn't expire while still in the window
+			seenUpdatedAt, seen, err := p.Store.ActivitySeen(*activity.ID)
+			if err != nil {
+				stats.Errors++
+				if !p.emitError(ctx, err) {
+					return
+				}
+				continue
+			}
+			if seen {
+				if activity.UpdatedAt.After(seenUpdatedAt) {
+					if err := p.Store.SetActivitySeen(*activity.ID, activity.UpdatedAt.Time); err != nil {
+						stats.Errors++
+						if !p.emitError(ctx, err) {
+							return
+						}
+					}
+				}
+				continue
+			}
+
+			// Emit the activity and remember it
+			if !p.emit(ctx, NewActivityEvent(report, activity)) {
+				return
+			}
+			stats.Activities++
+			if err := p.Store.SetActivitySeen(*activity.ID, activity.UpdatedAt.Time); err != nil {
+				stats.Errors++
+				if !p.emitError(ctx, err) {
+					return
+				}
+			}
+			if field, ok := activityField(activity); ok {
+				fieldActivities[field] = activity
+			}
+		}
+
+		// Emit the changes to tracked fields since we last saw the report, whether or not there was an activity for them
+		snapshot := newReportSnapshot(report)
+		previous, known, err := p.Store.Snapshot(*report.ID)
+		if err != nil {
+			stats.Errors++
+			if !p.emitError(ctx, err) {
+				return
+			}
+			continue
+		}
+		if known {
+			for _, event := range newChangeEvents(report, previous, snapshot, fieldActivities) {
+				if !p.emit(ctx, event) {
+					return
+				}
+				stats.Changes++
+			}
+		}
+		if err := p.Store.SetSnapshot(*report.ID, snapshot); err != nil {
+			stats.Errors++
+			if !p.emitError(ctx, err) {
+				return
+			}
+			continue
+		}
+
+		// Only remember the report once all of its activities were emitted so they're retried otherwise
+		if err := p.Store.SetLastActivity(*change.Report.ID, change.Report.LastActivityAt.Time); err != nil {
+			stats.Errors++
+			if !p.emitError(ctx, err) {
+				return
+			}
+		}
+	}
+
+	// Reports and activities updated before the next window can't be listed again, so forget them
+	if err := p.Store.Expire(updatedAt.Add(-p.Interval)); err != nil {
+		stats.Errors++
+		p.emitError(ctx, err)
+	}
+
+	// Only remember the poll if everything in it was emitted, so a failed poll's window is looked at again
+	if stats.Errors == 0 {
+		if err := p.Store.SetLastPoll(stats.StartedAt); err != nil {
+			stats.Errors++
+			p.emitError(ctx, err)
+		}
+	}
+}
+
+// reportChange is a listed report which needs to be fetched in full
+type reportChange struct {
+	Report h1.Report        // The report as listed
+	Seen   bool             // If we had seen the report before
+	Result chan reportFetch // Receives the fetched report
+}
+
+// reportFetch is the result of fetching a full report
+type reportFetch struct {
+	Report *h1.Report
+	Err    error
+}
+
+// fetch starts fetching the changed reports using at most Concurrency workers. Each result is sent to its change's Result channel
+func (p *Poller) fetch(ctx context.Context, changed []reportChange) {
+	jobs := make(chan reportChange, len(changed))
+	for _, change := range changed {
+		jobs <- change
+	}
+	close(jobs)
+
+	workers := p.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(changed) {
+		workers = len(changed)
+	}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for change := range jobs {
+				if err := ctx.Err(); err != nil {
+					change.Result <- reportFetch{Err: err}
+					continue
+				}
+				report, _, err := p.Client.Report.Get(*change.Report.ID)
+				change.Result <- reportFetch{Report: report, Err: err}
+			}
+		}()
+	}
+}
+
+// finishPoll records the stats of a poll
+func (p *Poller) finishPoll(stats *PollStats) {
+	stats.Duration = time.Since(stats.StartedAt)
+	p.mu.Lock()
+	p.lastPoll = *stats
+	p.mu.Unlock()
+	if p.OnPoll != nil {
+		p.OnPoll(*stats)
+	}
+}
+
+// LastPoll returns the stats of the most recently finished poll
+func (p *Poller) LastPoll() PollStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastPoll
+}
+
+// emitError emits an error event, returning false if the context finished first
+func (p *Poller) emitError(ctx context.Context, err error) bool {
+	return p.emit(ctx, Event{Type: EventError, Err: err})
+}
+
+// emit emits an event, returning false if the context finished first
+func (p *Poller) emit(ctx context.Context, event Event) bool {
+	select {
+	case p.eventChan <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
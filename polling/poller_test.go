@@ -0,0 +1,549 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package polling
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeActivity is an activity served by newFakeServer
+type fakeActivity struct {
+	ID        string
+	Type      string // Defaults to a comment
+	UpdatedAt time.Time
+}
+
+// fakeReport is a report served by newFakeServer
+type fakeReport struct {
+	ID             string
+	CreatedAt      time.Time
+	LastActivityAt time.Time
+	State          string // Defaults to new
+	Severity       string // The severity rating, if any
+	Assignee       string // The assigned user's username, if any
+	Activities     []fakeActivity
+}
+
+// newFakeServer serves the reports returned by the reports function for listing and getting reports
+func newFakeServer(reports func() []fakeReport) *httptest.Server {
+	attributes := func(report fakeReport) string {
+		state := report.State
+		if state == "" {
+			state = h1.ReportStateNew
+		}
+		return fmt.Sprintf(`"title":"Report %s","state":%q,"created_at":%q,"last_activity_at":%q`,
+			report.ID, state, report.CreatedAt.Format(time.RFC3339), report.LastActivityAt.Format(time.RFC3339))
+	}
+	relationships := func(report fakeReport) []string {
+		var relationships []string
+		if report.Severity != "" {
+			relationships = append(relationships, fmt.Sprintf(`"severity":{"data":{"id":"1","type":"severity","attributes":{"rating":%q}}}`, report.Severity))
+		}
+		if report.Assignee != "" {
+			relationships = append(relationships, fmt.Sprintf(`"assignee":{"data":{"id":"1","type":"user","attributes":{"username":%q}}}`, report.Assignee))
+		}
+		return relationships
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/reports" {
+			var data []string
+			after, _ := time.Parse(time.RFC3339, r.URL.Query().Get("filter[last_activity_at__gt]"))
+			for _, report := range reports() {
+				if !report.LastActivityAt.After(after) {
+					continue
+				}
+				data = append(data, fmt.Sprintf(`{"id":%q,"type":"report","attributes":{%s},"relationships":{%s}}`,
+					report.ID, attributes(report), strings.Join(relationships(report), ",")))
+			}
+			fmt.Fprintf(w, `{"data":[%s],"links":{}}`, strings.Join(data, ","))
+			return
+		}
+		for _, report := range reports() {
+			if r.URL.Path != "/reports/"+report.ID {
+				continue
+			}
+			activities := []string{}
+			for _, activity := range report.Activities {
+				activityType := activity.Type
+				if activityType == "" {
+					activityType = h1.ActivityCommentType
+				}
+				updatedAt := activity.UpdatedAt.Format(time.RFC3339)
+				activities = append(activities, fmt.Sprintf(`{"id":%q,"type":%q,"attributes":{"message":"Comment!","internal":false,"created_at":%q,"updated_at":%q}}`,
+					activity.ID, activityType, updatedAt, updatedAt))
+			}
+			fmt.Fprintf(w, `{"data":{"id":%q,"type":"report","attributes":{%s},"relationships":{%s}}}`,
+				report.ID, attributes(report), strings.Join(append(relationships(report), `"activities":{"data":[`+strings.Join(activities, ",")+`]}`), ","))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+}
+
+// newFakePoller returns a poller for a fake server with buffered channels
+func newFakePoller(server *httptest.Server) *Poller {
+	client := h1.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return NewPoller(client, h1.ReportListFilter{}, time.Minute, 2*time.Minute, 100)
+}
+
+// drainEvents drains the events emitted so far, describing new reports as "report <id>", activities by their ID, changes as "<field> <before> > <after> (<activity id>)" and errors as "error"
+func drainEvents(poller *Poller) (events []string) {
+	for len(poller.eventChan) > 0 {
+		event := <-poller.eventChan
+		switch {
+		case event.Err != nil:
+			events = append(events, "error")
+		case event.Type == EventReportChanged:
+			activityID := "none"
+			if event.Activity != nil {
+				activityID = *event.Activity.ID
+			}
+			events = append(events, fmt.Sprintf("%s %s > %s (%s)", event.Field, event.Before, event.After, activityID))
+		case event.Activity != nil:
+			events = append(events, *event.Activity.ID)
+		default:
+			events = append(events, "report "+*event.Report.ID)
+		}
+	}
+	return events
+}
+
+func newErrorPoller(bufferSize int) (*Poller, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	client := h1.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return NewPoller(client, h1.ReportListFilter{}, time.Hour, 2*time.Hour, bufferSize), server.Close
+}
+
+func Test_Poller_Run_context(t *testing.T) {
+	poller, closeServer := newErrorPoller(1)
+	defer closeServer()
+
+	// Verify that a buffered error doesn't block and the context ends polling
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() {
+		result <- poller.Run(ctx)
+	}()
+	event, ok := <-poller.Events()
+	assert.Equal(t, EventError, event.Type)
+	assert.NotNil(t, event.Err)
+	assert.True(t, ok)
+	cancel()
+	assert.Equal(t, context.Canceled, <-result)
+
+	// Verify that the channel is closed
+	_, ok = <-poller.Events()
+	assert.False(t, ok)
+
+	// Verify that a poller can only be run once
+	assert.NotNil(t, poller.Run(context.Background()))
+}
+
+func Test_Poller_Stop(t *testing.T) {
+	poller, closeServer := newErrorPoller(0)
+	defer closeServer()
+
+	// Verify that stopping a poller blocked on an unread channel works
+	result := make(chan error)
+	go func() {
+		result <- poller.Run(context.Background())
+	}()
+	time.Sleep(50 * time.Millisecond)
+	poller.Stop()
+	assert.Nil(t, <-result)
+	_, ok := <-poller.Events()
+	assert.False(t, ok)
+
+	// Verify that stopping again is fine
+	poller.Stop()
+}
+
+func Test_Poller_Stop_beforeRun(t *testing.T) {
+	poller, closeServer := newErrorPoller(0)
+	defer closeServer()
+
+	poller.Stop()
+	assert.Nil(t, poller.Run(context.Background()))
+	_, ok := <-poller.Events()
+	assert.False(t, ok)
+}
+
+func Test_Poller_Run_interval(t *testing.T) {
+	poller, closeServer := newErrorPoller(0)
+	defer closeServer()
+
+	// Verify that a poller without an interval fails rather than panicking
+	poller.Interval = 0
+	assert.EqualError(t, poller.Run(context.Background()), "poller interval must be positive")
+	_, ok := <-poller.Events()
+	assert.False(t, ok)
+}
+
+func Test_Poller_update_store(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	reports := []fakeReport{
+		fakeReport{
+			ID:             "1",
+			CreatedAt:      now.Add(-time.Minute),
+			LastActivityAt: now,
+			Activities: []fakeActivity{
+				fakeActivity{ID: "10", UpdatedAt: now.Add(-time.Minute)},
+				fakeActivity{ID: "11", UpdatedAt: now},
+			},
+		},
+	}
+	server := newFakeServer(func() []fakeReport { return reports })
+	defer server.Close()
+
+	// Verify that the report and its activities are emitted
+	store := NewMemoryStore()
+	poller := newFakePoller(server)
+	poller.Store = store
+	poller.update(context.Background())
+	assert.Equal(t, []string{"report 1", "10", "11"}, drainEvents(poller))
+
+	// Verify that a new poller resuming from the store doesn't emit them again
+	reports[0].LastActivityAt = now.Add(time.Second)
+	reports[0].Activities = append(reports[0].Activities, fakeActivity{ID: "12", UpdatedAt: now.Add(time.Second)})
+	poller = newFakePoller(server)
+	poller.Store = store
+	poller.update(context.Background())
+	assert.Equal(t, []string{"12"}, drainEvents(poller))
+}
+
+func Test_Poller_update_restartAfterGap(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	reports := []fakeReport{
+		fakeReport{
+			ID:             "1",
+			CreatedAt:      now.Add(-9 * time.Minute),
+			LastActivityAt: now.Add(-8 * time.Minute),
+			Activities: []fakeActivity{
+				fakeActivity{ID: "10", UpdatedAt: now.Add(-8 * time.Minute)},
+			},
+		},
+	}
+	server := newFakeServer(func() []fakeReport { return reports })
+	defer server.Close()
+
+	// Verify that a poller restarted after longer than its window looks back to the last successful poll
+	store := NewMemoryStore()
+	require.Nil(t, store.SetLastPoll(now.Add(-10*time.Minute)))
+	poller := newFakePoller(server)
+	poller.Store = store
+	poller.update(context.Background())
+	assert.Equal(t, []string{"report 1", "10"}, drainEvents(poller))
+	lastPoll, seen, err := store.LastPoll()
+	assert.Nil(t, err)
+	assert.True(t, seen)
+	assert.True(t, lastPoll.After(now.Add(-time.Second)))
+
+	// Verify that a failed poll isn't remembered
+	errorPoller, closeServer := newErrorPoller(10)
+	defer closeServer()
+	errorPoller.Store = store
+	errorPoller.update(context.Background())
+	assert.Equal(t, []string{"error"}, drainEvents(errorPoller))
+	failedLastPoll, _, _ := store.LastPoll()
+	assert.True(t, lastPoll.Equal(failedLastPoll))
+}
+
+func Test_Poller_update_overlappingWindows(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	reports := []fakeReport{
+		fakeReport{
+			ID:             "1",
+			CreatedAt:      now.Add(-time.Hour),
+			LastActivityAt: now,
+			Activities: []fakeActivity{
+				fakeActivity{ID: "10", UpdatedAt: now.Add(-time.Hour)},
+				fakeActivity{ID: "11", UpdatedAt: now},
+			},
+		},
+	}
+	server := newFakeServer(func() []fakeReport { return reports })
+	defer server.Close()
+	poller := newFakePoller(server)
+
+	// Verify that only activities in the window are emitted, and old reports aren't new
+	poller.update(context.Background())
+	assert.Equal(t, []string{"11"}, drainEvents(poller))
+
+	// Verify that polling the same window again doesn't emit anything
+	poller.update(context.Background())
+	assert.Nil(t, drainEvents(poller))
+
+	// Verify that an overlapping window with new activity only emits the new activity, even though the report changed
+	reports[0].LastActivityAt = now.Add(time.Second)
+	reports[0].Activities = append(reports[0].Activities, fakeActivity{ID: "12", UpdatedAt: now.Add(time.Second)})
+	poller.update(context.Background())
+	assert.Equal(t, []string{"12"}, drainEvents(poller))
+	poller.update(context.Background())
+	assert.Nil(t, drainEvents(poller))
+}
+
+func Test_Poller_update_clockSkew(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	reports := []fakeReport{
+		// HackerOne's clock is three minutes behind ours, so the activity looks older than the window
+		fakeReport{
+			ID:             "1",
+			CreatedAt:      now.Add(-3 * time.Minute),
+			LastActivityAt: now.Add(-3 * time.Minute),
+			Activities: []fakeActivity{
+				fakeActivity{ID: "10", UpdatedAt: now.Add(-3 * time.Minute)},
+			},
+		},
+		// HackerOne's clock is ahead of ours, so the activity is in the future
+		fakeReport{
+			ID:             "2",
+			CreatedAt:      now.Add(time.Minute),
+			LastActivityAt: now.Add(time.Minute),
+			Activities: []fakeActivity{
+				fakeActivity{ID: "20", UpdatedAt: now.Add(time.Minute)},
+			},
+		},
+	}
+	server := newFakeServer(func() []fakeReport { return reports })
+	defer server.Close()
+
+	// Verify that without allowing for skew the activity behind our clock is missed
+	poller := newFakePoller(server)
+	poller.ClockSkew = 0
+	poller.update(context.Background())
+	assert.Equal(t, []string{"report 2", "20"}, drainEvents(poller))
+
+	// Verify that allowing for skew emits both, once each
+	poller = newFakePoller(server)
+	poller.ClockSkew = 2 * time.Minute
+	poller.update(context.Background())
+	assert.Equal(t, []string{"report 1", "10", "report 2", "20"}, drainEvents(poller))
+	poller.update(context.Background())
+	assert.Nil(t, drainEvents(poller))
+}
+
+func Test_Poller_update_editedActivity(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	reports := []fakeReport{
+		fakeReport{
+			ID:             "1",
+			CreatedAt:      now.Add(-time.Hour),
+			LastActivityAt: now.Add(-time.Hour),
+			Activities: []fakeActivity{
+				fakeActivity{ID: "10", UpdatedAt: now.Add(-time.Hour)},
+			},
+		},
+	}
+	server := newFakeServer(func() []fakeReport { return reports })
+	defer server.Close()
+	poller := newFakePoller(server)
+
+	// Verify that an old activity edited into the window is emitted once
+	reports[0].LastActivityAt = now
+	reports[0].Activities[0].UpdatedAt = now
+	poller.update(context.Background())
+	assert.Equal(t, []string{"10"}, drainEvents(poller))
+
+	// Verify that editing it again doesn't emit it again, and its new time is remembered
+	reports[0].LastActivityAt = now.Add(time.Second)
+	reports[0].Activities[0].UpdatedAt = now.Add(time.Second)
+	poller.update(context.Background())
+	assert.Nil(t, drainEvents(poller))
+	updatedAt, seen, _ := poller.Store.ActivitySeen("10")
+	assert.True(t, seen)
+	assert.True(t, now.Add(time.Second).Equal(updatedAt))
+
+	// Verify that expiring up to the first edit doesn't forget it
+	poller.Store.Expire(now.Add(time.Millisecond))
+	poller.update(context.Background())
+	assert.Nil(t, drainEvents(poller))
+}
+
+func Test_Poller_update_expire(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	server := newFakeServer(func() []fakeReport { return nil })
+	defer server.Close()
+	poller := newFakePoller(server)
+
+	// Verify that reports and activities outside of the window are forgotten
+	poller.Store.SetLastActivity("1", now.Add(-time.Hour))
+	poller.Store.SetActivitySeen("10", now.Add(-time.Hour))
+	poller.Store.SetLastActivity("2", now)
+	poller.Store.SetActivitySeen("20", now)
+	poller.update(context.Background())
+	_, seen, _ := poller.Store.LastActivity("1")
+	assert.False(t, seen)
+	_, seen, _ = poller.Store.ActivitySeen("10")
+	assert.False(t, seen)
+	_, seen, _ = poller.Store.LastActivity("2")
+	assert.True(t, seen)
+	_, seen, _ = poller.Store.ActivitySeen("20")
+	assert.True(t, seen)
+}
+
+func Test_Poller_update_concurrency(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	var reports []fakeReport
+	for i := 1; i <= 8; i++ {
+		id := fmt.Sprintf("%d", i)
+		reports = append(reports, fakeReport{
+			ID:             id,
+			CreatedAt:      now,
+			LastActivityAt: now,
+			Activities: []fakeActivity{
+				fakeActivity{ID: id + "0", UpdatedAt: now},
+				fakeActivity{ID: id + "1", UpdatedAt: now},
+			},
+		})
+	}
+	server := newFakeServer(func() []fakeReport { return reports })
+	defer server.Close()
+
+	// Slow down earlier reports more than later ones and track how many are fetched at once
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	handler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/reports" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/reports/"))
+		time.Sleep(time.Duration(9-id) * 5 * time.Millisecond)
+		handler.ServeHTTP(w, r)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+
+	var polls []PollStats
+	poller := newFakePoller(server)
+	poller.Concurrency = 3
+	poller.OnPoll = func(stats PollStats) {
+		polls = append(polls, stats)
+	}
+	poller.update(context.Background())
+
+	// Verify that reports and activities are emitted in order
+	var expected []string
+	for i := 1; i <= 8; i++ {
+		expected = append(expected, fmt.Sprintf("report %d", i), fmt.Sprintf("%d0", i), fmt.Sprintf("%d1", i))
+	}
+	assert.Equal(t, expected, drainEvents(poller))
+
+	// Verify that fetches were concurrent but bounded
+	assert.True(t, maxInFlight > 1)
+	assert.True(t, maxInFlight <= 3)
+
+	// Verify that the stats were recorded
+	require.Len(t, polls, 1)
+	stats := poller.LastPoll()
+	assert.Equal(t, polls[0], stats)
+	assert.Equal(t, 8, stats.Reports)
+	assert.Equal(t, 8, stats.Fetched)
+	assert.Equal(t, 8, stats.NewReports)
+	assert.Equal(t, 16, stats.Activities)
+	assert.Equal(t, 0, stats.Errors)
+	assert.True(t, stats.Duration > 0)
+}
+
+func Test_Poller_update_stats_error(t *testing.T) {
+	poller, closeServer := newErrorPoller(1)
+	defer closeServer()
+
+	poller.update(context.Background())
+	assert.Equal(t, 1, poller.LastPoll().Errors)
+	assert.Equal(t, 0, poller.LastPoll().Reports)
+}
+
+func Test_Poller_update_changes(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	reports := []fakeReport{
+		fakeReport{
+			ID:             "1",
+			CreatedAt:      now.Add(-time.Hour),
+			LastActivityAt: now,
+			Activities: []fakeActivity{
+				fakeActivity{ID: "10", UpdatedAt: now},
+			},
+		},
+	}
+	server := newFakeServer(func() []fakeReport { return reports })
+	defer server.Close()
+	poller := newFakePoller(server)
+
+	// Verify that nothing has changed the first time we see a report
+	poller.update(context.Background())
+	assert.Equal(t, []string{"10"}, drainEvents(poller))
+
+	// Verify that changes are emitted after the activities, with the activity which caused them
+	reports[0].LastActivityAt = now.Add(time.Second)
+	reports[0].State = h1.ReportStateTriaged
+	reports[0].Severity = h1.SeverityRatingHigh
+	reports[0].Assignee = "api-example"
+	reports[0].Activities = append(reports[0].Activities,
+		fakeActivity{ID: "11", Type: h1.ActivityBugTriagedType, UpdatedAt: now.Add(time.Second)},
+		fakeActivity{ID: "12", Type: h1.ActivityUserAssignedToBugType, UpdatedAt: now.Add(time.Second)},
+	)
+	poller.update(context.Background())
+	assert.Equal(t, []string{
+		"11",
+		"12",
+		"state new > triaged (11)",
+		"severity unrated > high (none)",
+		"assignee unassigned > api-example (12)",
+	}, drainEvents(poller))
+
+	// Verify that changes without any activity are emitted
+	reports[0].LastActivityAt = now.Add(2 * time.Second)
+	reports[0].State = h1.ReportStateResolved
+	poller.update(context.Background())
+	assert.Equal(t, []string{"state triaged > resolved (none)"}, drainEvents(poller))
+	assert.Equal(t, 1, poller.LastPoll().Changes)
+
+	// Verify that nothing is emitted when nothing changed
+	reports[0].LastActivityAt = now.Add(3 * time.Second)
+	poller.update(context.Background())
+	assert.Nil(t, drainEvents(poller))
+}
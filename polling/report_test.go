@@ -0,0 +1,113 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package polling
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_Start(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	var mu sync.Mutex
+	reports := []fakeReport{
+		fakeReport{
+			ID:             "1",
+			CreatedAt:      now.Add(-time.Minute),
+			LastActivityAt: now,
+			Activities: []fakeActivity{
+				fakeActivity{ID: "10", UpdatedAt: now},
+			},
+		},
+	}
+	server := newFakeServer(func() []fakeReport {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]fakeReport{}, reports...)
+	})
+	defer server.Close()
+
+	client := h1.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	errorChan, reportChan, activityChan := Start(client, h1.ReportListFilter{}, 10*time.Millisecond, time.Hour)
+
+	// receiveUntil collects what Start emits until the activity with the given ID
+	var received []string
+	receiveUntil := func(activityID string) {
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case err := <-errorChan:
+				require.Nil(t, err)
+			case report := <-reportChan:
+				received = append(received, "report "+*report.ID+" "+*report.State)
+			case activity := <-activityChan:
+				received = append(received, "activity "+*activity.ID)
+				if *activity.ID == activityID {
+					return
+				}
+			case <-timeout:
+				require.FailNow(t, "timed out", "received %v", received)
+			}
+		}
+	}
+	receiveUntil("10")
+
+	// A change with an activity only emits the activity, once
+	mu.Lock()
+	reports[0].LastActivityAt = now.Add(time.Second)
+	reports[0].State = h1.ReportStateTriaged
+	reports[0].Severity = h1.SeverityRatingHigh
+	reports[0].Activities = append(reports[0].Activities,
+		fakeActivity{ID: "11", Type: h1.ActivityBugTriagedType, UpdatedAt: now.Add(time.Second)},
+	)
+	mu.Unlock()
+	receiveUntil("11")
+
+	// A change without an activity isn't emitted as a new report. Report 2 is listed after it, so anything misrouted arrives first
+	mu.Lock()
+	reports[0].LastActivityAt = now.Add(2 * time.Second)
+	reports[0].State = h1.ReportStateResolved
+	reports = append(reports, fakeReport{
+		ID:             "2",
+		CreatedAt:      now,
+		LastActivityAt: now.Add(2 * time.Second),
+		Activities: []fakeActivity{
+			fakeActivity{ID: "20", UpdatedAt: now.Add(2 * time.Second)},
+		},
+	})
+	mu.Unlock()
+	receiveUntil("20")
+
+	assert.Equal(t, []string{
+		"report 1 new",
+		"activity 10",
+		"activity 11",
+		"report 2 new",
+		"activity 20",
+	}, received)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package polling
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/metrics"
+
+	"context"
+	"time"
+)
+
+// SLAEventType represent the possible types of an SLAEvent
+const (
+	SLAEventWarning string = "warning" // The target will be missed soon
+	SLAEventBreach  string = "breach"  // The target has been missed
+	SLAEventError   string = "error"   // The reports couldn't be listed
+)
+
+// SLAEvent is emitted when a pending metric of an open report approaches or exceeds a target, or when the watcher fails
+type SLAEvent struct {
+	Type      string
+	Report    *h1.Report
+	Target    metrics.Target
+	Elapsed   time.Duration // How long the metric has taken so far
+	Remaining time.Duration // How long until the target is missed, negative once breached
+	Err       error         // Set for SLAEventError
+}
+
+// slaReportState is what we last knew about a report. When it changes, previously emitted events are forgotten so they can be emitted again
+type slaReportState struct {
+	Severity              string
+	State                 string
+	LastProgramActivityAt time.Time
+}
+
+// SLAWatcher polls open reports until it is stopped, emitting SLAEvents for targets which will be or have been missed
+type SLAWatcher struct {
+	Client   *h1.Client          // The h1.Client to use when making requests
+	Filter   h1.ReportListFilter // The h1.ReportListOptions to use when making requests
+	Targets  []metrics.Target    // The targets to watch
+	Warning  time.Duration       // How long before a target is missed to emit a warning, zero disables warnings
+	Interval time.Duration       // How often to poll
+
+	reports   map[string]slaReportState // What we last knew about each open report
+	emitted   map[string]map[int]string // The last event type emitted for each report and target index
+	eventChan chan SLAEvent
+	loop      *Loop
+}
+
+// NewSLAWatcher creates an SLAWatcher. Its channel holds up to bufferSize events before the watcher waits for them to be read.
+//
+// If the filter has no states, reports which are new, triaged or need more info are watched, as well as resolved reports if any target is for time-to-bounty. Each event is only emitted once per report and target until the report's severity, state or last program activity changes.
+func NewSLAWatcher(client *h1.Client, filter h1.ReportListFilter, targets []metrics.Target, warning time.Duration, interval time.Duration, bufferSize int) *SLAWatcher {
+	if len(filter.State) == 0 {
+		filter.State = []string{h1.ReportStateNew, h1.ReportStateTriaged, h1.ReportStateNeedsMoreInfo}
+		for _, target := range targets {
+			if target.Metric == metrics.MetricTimeToBounty {
+				filter.State = append(filter.State, h1.ReportStateResolved)
+				break
+			}
+		}
+	}
+	return &SLAWatcher{
+		Client:    client,
+		Filter:    filter,
+		Targets:   targets,
+		Warning:   warning,
+		Interval:  interval,
+		reports:   make(map[string]slaReportState),
+		emitted:   make(map[string]map[int]string),
+		eventChan: make(chan SLAEvent, bufferSize),
+		loop:      NewLoop("SLA watcher"),
+	}
+}
+
+// Events returns the channel events are emitted on. The channel is closed when the watcher stops
+func (w *SLAWatcher) Events() <-chan SLAEvent {
+	return w.eventChan
+}
+
+// Run polls immediately and then at the interval until the context is done or Stop is called, then closes the events channel.
+// It returns the context's error if the context ended polling, and nil if Stop did. An SLAWatcher can only be run once.
+func (w *SLAWatcher) Run(ctx context.Context) error {
+	return w.loop.Run(ctx, w.Interval, func(ctx context.Context) {
+		w.update(ctx, time.Now().UTC())
+	}, func() {
+		close(w.eventChan)
+	})
+}
+
+// Stop stops the watcher and waits for Run to return. It is safe to call more than once
+func (w *SLAWatcher) Stop() {
+	w.loop.Stop()
+}
+
+// Perform a poll. It returns early if the context is done.
+func (w *SLAWatcher) update(ctx context.Context, now time.Time) {
+	allReports, err := ListReports(w.Client, w.Filter)
+	if err != nil {
+		w.emit(ctx, SLAEvent{Type: SLAEventError, Err: err})
+		return
+	}
+
+	// Forget reports which are no longer open
+	open := make(map[string]bool)
+	for _, report := range allReports {
+		open[*report.ID] = true
+	}
+	for id := range w.reports {
+		if !open[id] {
+			delete(w.reports, id)
+			delete(w.emitted, id)
+		}
+	}
+
+	// Loop each open report
+	for idx := range allReports {
+		report := &allReports[idx]
+
+		// If anything we track changed, forget the events we emitted
+		state := newSLAReportState(report)
+		if known, seen := w.reports[*report.ID]; !seen || known != state {
+			w.reports[*report.ID] = state
+			w.emitted[*report.ID] = make(map[int]string)
+		}
+		emitted := w.emitted[*report.ID]
+
+		// Loop each target which applies to the report
+		for targetIdx, target := range w.Targets {
+			if !target.Applies(report) {
+				continue
+			}
+
+			// Only pending metrics can still be acted on
+			elapsed, done, ok := metrics.Elapsed(report, target.Metric, now)
+			if !ok || done {
+				continue
+			}
+
+			// Determine which event, if any, is due
+			remaining := target.Duration - elapsed
+			var eventType string
+			switch {
+			case remaining < 0:
+				eventType = SLAEventBreach
+			case w.Warning > 0 && remaining <= w.Warning:
+				eventType = SLAEventWarning
+			default:
+				continue
+			}
+
+			// If we already emitted it, or the breach which follows it, skip it
+			if emitted[targetIdx] == eventType || emitted[targetIdx] == SLAEventBreach {
+				continue
+			}
+
+			// Emit the event, only remembering it if it was read
+			if !w.emit(ctx, SLAEvent{
+				Type:      eventType,
+				Report:    report,
+				Target:    target,
+				Elapsed:   elapsed,
+				Remaining: remaining,
+			}) {
+				return
+			}
+			emitted[targetIdx] = eventType
+		}
+	}
+}
+
+// emit emits an event, returning false if the context finished first
+func (w *SLAWatcher) emit(ctx context.Context, event SLAEvent) bool {
+	select {
+	case w.eventChan <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// newSLAReportState returns the tracked state of a report
+func newSLAReportState(report *h1.Report) (state slaReportState) {
+	state.Severity = metrics.SeverityRating(report)
+	if report.State != nil {
+		state.State = *report.State
+	}
+	if report.LastProgramActivityAt != nil {
+		state.LastProgramActivityAt = report.LastProgramActivityAt.Time
+	}
+	return state
+}
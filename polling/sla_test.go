@@ -0,0 +1,166 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package polling
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/metrics"
+
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const slaReportList = `{
+  "data": [
+    {
+      "id": "1337",
+      "type": "report",
+      "attributes": {
+        "title": "XSS in login form",
+        "state": "new",
+        "created_at": "2016-02-02T00:00:00.000Z"%s
+      },
+      "relationships": {
+        "severity": {
+          "data": {
+            "id": "57",
+            "type": "severity",
+            "attributes": {
+              "rating": "high"
+            }
+          }
+        }
+      }
+    }
+  ],
+  "links": {}
+}`
+
+func Test_SLAWatcher_update(t *testing.T) {
+	var extra string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"new", "triaged", "needs-more-info"}, r.URL.Query()["filter[state][]"])
+		fmt.Fprintf(w, slaReportList, extra)
+	}))
+	defer server.Close()
+
+	client := h1.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	targets := []metrics.Target{
+		metrics.Target{Metric: metrics.MetricTimeToFirstResponse, Severity: h1.SeverityRatingHigh, Duration: 4 * time.Hour},
+		metrics.Target{Metric: metrics.MetricTimeToFirstResponse, Severity: h1.SeverityRatingLow, Duration: time.Hour},
+	}
+	watcher := NewSLAWatcher(client, h1.ReportListFilter{}, targets, time.Hour, time.Hour, 10)
+	ctx := context.Background()
+	created := h1.NewTimestamp("2016-02-02T00:00:00Z").Time
+
+	// Verify that nothing is emitted while the target is far away
+	watcher.update(ctx, created.Add(2*time.Hour))
+	assert.Len(t, watcher.eventChan, 0)
+
+	// Verify that a warning is emitted once before the target is missed
+	watcher.update(ctx, created.Add(3*time.Hour))
+	watcher.update(ctx, created.Add(3*time.Hour+time.Minute))
+	require.Len(t, watcher.eventChan, 1)
+	event := <-watcher.eventChan
+	assert.Equal(t, SLAEventWarning, event.Type)
+	assert.Equal(t, "1337", *event.Report.ID)
+	assert.Equal(t, targets[0], event.Target)
+	assert.Equal(t, 3*time.Hour, event.Elapsed)
+	assert.Equal(t, time.Hour, event.Remaining)
+
+	// Verify that a breach is emitted once when the target is missed
+	watcher.update(ctx, created.Add(5*time.Hour))
+	watcher.update(ctx, created.Add(6*time.Hour))
+	require.Len(t, watcher.eventChan, 1)
+	event = <-watcher.eventChan
+	assert.Equal(t, SLAEventBreach, event.Type)
+	assert.Equal(t, 5*time.Hour, event.Elapsed)
+	assert.Equal(t, -time.Hour, event.Remaining)
+
+	// Verify that nothing is emitted once the metric is done
+	extra = `,
+        "first_program_activity_at": "2016-02-02T07:00:00.000Z",
+        "last_program_activity_at": "2016-02-02T07:00:00.000Z"`
+	watcher.update(ctx, created.Add(8*time.Hour))
+	assert.Len(t, watcher.eventChan, 0)
+}
+
+func Test_SLAWatcher_update_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := h1.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	targets := []metrics.Target{
+		metrics.Target{Metric: metrics.MetricTimeToBounty, Duration: time.Hour},
+	}
+	watcher := NewSLAWatcher(client, h1.ReportListFilter{}, targets, 0, time.Hour, 10)
+	assert.Contains(t, watcher.Filter.State, h1.ReportStateResolved)
+	watcher.update(context.Background(), time.Now())
+	require.Len(t, watcher.eventChan, 1)
+	event := <-watcher.eventChan
+	assert.Equal(t, SLAEventError, event.Type)
+	assert.NotNil(t, event.Err)
+}
+
+func Test_SLAWatcher_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, slaReportList, "")
+	}))
+	defer server.Close()
+
+	client := h1.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	targets := []metrics.Target{
+		metrics.Target{Metric: metrics.MetricTimeToFirstResponse, Duration: time.Hour},
+	}
+
+	// Verify that Stop ends Run and closes the channel, even while an event is waiting to be read
+	watcher := NewSLAWatcher(client, h1.ReportListFilter{}, targets, 0, time.Hour, 0)
+	result := make(chan error)
+	go func() {
+		result <- watcher.Run(context.Background())
+	}()
+	event := <-watcher.Events()
+	assert.Equal(t, SLAEventBreach, event.Type)
+	watcher.Stop()
+	assert.Nil(t, <-result)
+	_, open := <-watcher.Events()
+	assert.False(t, open)
+	watcher.Stop()
+	assert.EqualError(t, watcher.Run(context.Background()), "SLA watcher has already been run")
+
+	// Verify that cancelling the context ends Run while nobody reads the events
+	watcher = NewSLAWatcher(client, h1.ReportListFilter{}, targets, 0, time.Hour, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, watcher.Run(ctx))
+}
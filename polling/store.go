@@ -0,0 +1,190 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package polling
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+
+	"sync"
+	"time"
+)
+
+// Store persists what a Poller has already seen so polling can resume after a restart
+type Store interface {
+	// LastActivity returns the last activity time recorded for a report
+	LastActivity(reportID string) (lastActivityAt time.Time, seen bool, err error)
+	// SetLastActivity records the last activity time of a report
+	SetLastActivity(reportID string, lastActivityAt time.Time) error
+	// ActivitySeen returns the updated time recorded for an emitted activity
+	ActivitySeen(activityID string) (updatedAt time.Time, seen bool, err error)
+	// SetActivitySeen records that an activity was emitted along with its updated time
+	SetActivitySeen(activityID string, updatedAt time.Time) error
+	// Snapshot returns the fields recorded for a report
+	Snapshot(reportID string) (snapshot ReportSnapshot, seen bool, err error)
+	// SetSnapshot records the fields of a report
+	SetSnapshot(reportID string, snapshot ReportSnapshot) error
+	// LastPoll returns when the last successful poll started
+	LastPoll() (startedAt time.Time, seen bool, err error)
+	// SetLastPoll records when the last successful poll started
+	SetLastPoll(startedAt time.Time) error
+	// Expire forgets reports last active and activities updated before a time. Snapshots are only forgotten for closed reports
+	Expire(before time.Time) error
+	// Flush persists any changes which have not been persisted yet
+	Flush() error
+}
+
+// ReportSnapshot is what we knew about a report's fields when we last saw it, used to detect changes
+type ReportSnapshot struct {
+	State          string    `json:"state"`
+	Severity       string    `json:"severity"`
+	Assignee       string    `json:"assignee"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+}
+
+// closedStates are the states of reports which are closed
+var closedStates = map[string]bool{
+	h1.ReportStateResolved:      true,
+	h1.ReportStateNotApplicable: true,
+	h1.ReportStateInformative:   true,
+	h1.ReportStateDuplicate:     true,
+	h1.ReportStateSpam:          true,
+}
+
+// MemoryStore is a Store which only lives as long as the process
+type MemoryStore struct {
+	mu         sync.Mutex
+	reports    map[string]time.Time // The last activity we know about on that report
+	activities map[string]time.Time // The updated time of activities we've emitted
+	snapshots  map[string]ReportSnapshot
+	lastPoll   time.Time // When the last successful poll started, zero if there wasn't one
+	onChange   func()    // Called with the lock held after each change, used by FileStore to track when it needs flushing
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		reports:    make(map[string]time.Time),
+		activities: make(map[string]time.Time),
+		snapshots:  make(map[string]ReportSnapshot),
+	}
+}
+
+// LastActivity returns the last activity time recorded for a report
+func (s *MemoryStore) LastActivity(reportID string) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lastActivityAt, seen := s.reports[reportID]
+	return lastActivityAt, seen, nil
+}
+
+// SetLastActivity records the last activity time of a report
+func (s *MemoryStore) SetLastActivity(reportID string, lastActivityAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports[reportID] = lastActivityAt
+	s.changed()
+	return nil
+}
+
+// ActivitySeen returns the updated time recorded for an emitted activity
+func (s *MemoryStore) ActivitySeen(activityID string) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	updatedAt, seen := s.activities[activityID]
+	return updatedAt, seen, nil
+}
+
+// SetActivitySeen records that an activity was emitted along with its updated time
+func (s *MemoryStore) SetActivitySeen(activityID string, updatedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.activities[activityID] = updatedAt
+	s.changed()
+	return nil
+}
+
+// Snapshot returns the fields recorded for a report
+func (s *MemoryStore) Snapshot(reportID string) (ReportSnapshot, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot, seen := s.snapshots[reportID]
+	return snapshot, seen, nil
+}
+
+// SetSnapshot records the fields of a report
+func (s *MemoryStore) SetSnapshot(reportID string, snapshot ReportSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots[reportID] = snapshot
+	s.changed()
+	return nil
+}
+
+// LastPoll returns when the last successful poll started
+func (s *MemoryStore) LastPoll() (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastPoll, !s.lastPoll.IsZero(), nil
+}
+
+// SetLastPoll records when the last successful poll started
+func (s *MemoryStore) SetLastPoll(startedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastPoll = startedAt
+	s.changed()
+	return nil
+}
+
+// Expire forgets reports last active and activities updated before a time. Snapshots are only forgotten for closed reports
+func (s *MemoryStore) Expire(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, lastActivityAt := range s.reports {
+		if lastActivityAt.Before(before) {
+			delete(s.reports, id)
+		}
+	}
+	for id, updatedAt := range s.activities {
+		if updatedAt.Before(before) {
+			delete(s.activities, id)
+		}
+	}
+	for id, snapshot := range s.snapshots {
+		if closedStates[snapshot.State] && snapshot.LastActivityAt.Before(before) {
+			delete(s.snapshots, id)
+		}
+	}
+	s.changed()
+	return nil
+}
+
+// changed calls onChange if it's set, the caller must hold the lock
+func (s *MemoryStore) changed() {
+	if s.onChange != nil {
+		s.onChange()
+	}
+}
+
+// Flush does nothing as there is nowhere to persist to
+func (s *MemoryStore) Flush() error {
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package polling
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testStore(t *testing.T, store Store) {
+	now := time.Date(2016, 2, 2, 4, 5, 6, 0, time.UTC)
+
+	// Verify that the last poll is recorded
+	_, seen, err := store.LastPoll()
+	assert.Nil(t, err)
+	assert.False(t, seen)
+	assert.Nil(t, store.SetLastPoll(now))
+	lastPoll, seen, err := store.LastPoll()
+	assert.Nil(t, err)
+	assert.True(t, seen)
+	assert.True(t, now.Equal(lastPoll))
+
+	// Verify that unknown reports and activities aren't seen
+	_, seen, err = store.LastActivity("1")
+	assert.Nil(t, err)
+	assert.False(t, seen)
+	_, seen, err = store.ActivitySeen("2")
+	assert.Nil(t, err)
+	assert.False(t, seen)
+
+	// Verify that recorded reports and activities are seen
+	assert.Nil(t, store.SetLastActivity("1", now))
+	assert.Nil(t, store.SetLastActivity("4", now.Add(-time.Hour)))
+	assert.Nil(t, store.SetActivitySeen("2", now))
+	assert.Nil(t, store.SetActivitySeen("3", now.Add(-time.Hour)))
+	lastActivityAt, seen, err := store.LastActivity("1")
+	assert.Nil(t, err)
+	assert.True(t, seen)
+	assert.True(t, now.Equal(lastActivityAt))
+	updatedAt, seen, err := store.ActivitySeen("2")
+	assert.Nil(t, err)
+	assert.True(t, seen)
+	assert.True(t, now.Equal(updatedAt))
+
+	// Verify that snapshots are recorded
+	_, seen, err = store.Snapshot("1")
+	assert.Nil(t, err)
+	assert.False(t, seen)
+	open := ReportSnapshot{State: h1.ReportStateTriaged, Severity: h1.SeverityRatingHigh, Assignee: "api-example", LastActivityAt: now.Add(-time.Hour)}
+	closed := ReportSnapshot{State: h1.ReportStateResolved, LastActivityAt: now.Add(-time.Hour)}
+	assert.Nil(t, store.SetSnapshot("1", open))
+	assert.Nil(t, store.SetSnapshot("4", closed))
+	snapshot, seen, err := store.Snapshot("1")
+	assert.Nil(t, err)
+	assert.True(t, seen)
+	assert.Equal(t, open.State, snapshot.State)
+	assert.Equal(t, open.Assignee, snapshot.Assignee)
+
+	// Verify that only older reports and activities, and snapshots of closed reports, expire
+	assert.Nil(t, store.Expire(now.Add(-time.Minute)))
+	_, seen, _ = store.Snapshot("1")
+	assert.True(t, seen)
+	_, seen, _ = store.Snapshot("4")
+	assert.False(t, seen)
+	_, seen, _ = store.LastActivity("1")
+	assert.True(t, seen)
+	_, seen, _ = store.LastActivity("4")
+	assert.False(t, seen)
+	_, seen, _ = store.ActivitySeen("2")
+	assert.True(t, seen)
+	_, seen, _ = store.ActivitySeen("3")
+	assert.False(t, seen)
+	assert.Nil(t, store.Flush())
+}
+
+func Test_MemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func Test_FileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "polling")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "store.json")
+
+	// Verify that the store works and is created on flush
+	store, err := NewFileStore(path)
+	require.Nil(t, err)
+	testStore(t, store)
+	_, err = os.Stat(path)
+	assert.Nil(t, err)
+
+	// Verify that reopening the store keeps what was flushed
+	store, err = NewFileStore(path)
+	require.Nil(t, err)
+	_, seen, _ := store.LastActivity("1")
+	assert.True(t, seen)
+	_, seen, _ = store.ActivitySeen("2")
+	assert.True(t, seen)
+	_, seen, _ = store.ActivitySeen("3")
+	assert.False(t, seen)
+	snapshot, seen, _ := store.Snapshot("1")
+	assert.True(t, seen)
+	assert.Equal(t, h1.SeverityRatingHigh, snapshot.Severity)
+	_, seen, _ = store.LastPoll()
+	assert.True(t, seen)
+
+	// Verify that unflushed changes are lost
+	assert.Nil(t, store.SetActivitySeen("4", time.Now()))
+	store, err = NewFileStore(path)
+	require.Nil(t, err)
+	_, seen, _ = store.ActivitySeen("4")
+	assert.False(t, seen)
+
+	// Verify that a corrupt file fails
+	require.Nil(t, ioutil.WriteFile(path, []byte("{"), 0600))
+	_, err = NewFileStore(path)
+	assert.NotNil(t, err)
+
+	// Verify that an unreadable path fails
+	_, err = NewFileStore(dir)
+	assert.NotNil(t, err)
+}
+
+func Test_FileStore_concurrentFlush(t *testing.T) {
+	dir, err := ioutil.TempDir("", "polling")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "store.json")
+
+	// Verify that changes made while flushing are never lost
+	store, err := NewFileStore(path)
+	require.Nil(t, err)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			assert.Nil(t, store.SetActivitySeen(fmt.Sprint(i), time.Now()))
+		}
+	}()
+	for flushing := true; flushing; {
+		select {
+		case <-done:
+			flushing = false
+		default:
+		}
+		assert.Nil(t, store.Flush())
+	}
+
+	store, err = NewFileStore(path)
+	require.Nil(t, err)
+	for i := 0; i < 200; i++ {
+		_, seen, _ := store.ActivitySeen(fmt.Sprint(i))
+		assert.True(t, seen, "activity %d", i)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package reconcile combines HackerOne webhooks with periodic polling, so events from dropped webhooks are backfilled and every event is handled once.
+package reconcile
+
+import (
+	"github.com/uber-go/hackeroni/polling"
+
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultRetention is how long dispatched IDs are remembered without a poller to base it on. Webhooks older than a day are rejected, so it covers their redeliveries
+const defaultRetention = 24 * time.Hour
+
+// Source represent where an event was received from
+const (
+	SourceWebhook string = "webhook"
+	SourcePoller  string = "poller"
+)
+
+// Stats counts the events a Reconciler has received
+type Stats struct {
+	Webhook    int // New reports and activities dispatched as they arrived by webhook
+	Backfilled int // New reports and activities dispatched from a poll because their webhook never arrived
+	Duplicates int // New reports and activities dropped because they had already been dispatched
+}
+
+// Reconciler dispatches events from webhooks as they arrive, and from a Poller sweeping for anything the webhooks missed.
+// New reports and activities are deduplicated by ID; other events, such as errors and report changes, only come from the poller and are always dispatched.
+//
+// Use the Reconciler as the webhook.Handler's Handlers so it receives webhook events, then Run it.
+type Reconciler struct {
+	Poller    *polling.Poller    // The poller used to sweep for missed events, nil to only deduplicate webhooks. Its interval can be much longer than when polling alone
+	Handlers  polling.Dispatcher // Where events are dispatched once
+	Retention time.Duration      // How long to remember dispatched IDs, defaults to the poller's window and clock skew plus two intervals, or a day without a poller
+
+	dispatchMu sync.Mutex // Held while dispatching so handlers are called one at a time
+	mu         sync.Mutex
+	seen       map[string]time.Time // When each new report and activity key was dispatched
+	lastPrune  time.Time
+	stats      Stats
+	now        func() time.Time // Used to fake the time in tests
+}
+
+// New returns a Reconciler which sweeps using poller and dispatches to handlers
+func New(poller *polling.Poller, handlers polling.Dispatcher) *Reconciler {
+	return &Reconciler{
+		Poller:   poller,
+		Handlers: handlers,
+		seen:     make(map[string]time.Time),
+	}
+}
+
+// Dispatch receives an event from a webhook
+func (r *Reconciler) Dispatch(event polling.Event) {
+	r.receive(event, SourceWebhook)
+}
+
+// Run runs the poller, dispatching the events it finds which weren't received by webhook, until the poller stops. It returns the poller's error,
+// or an error if there's no poller
+func (r *Reconciler) Run(ctx context.Context) error {
+	if r.Poller == nil {
+		return errors.New("reconcile: no poller to run")
+	}
+	result := make(chan error, 1)
+	go func() {
+		result <- r.Poller.Run(ctx)
+	}()
+	for event := range r.Poller.Events() {
+		r.receive(event, SourcePoller)
+	}
+	return <-result
+}
+
+// Stats returns how many events have been received from each source
+func (r *Reconciler) Stats() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// receive dispatches an event unless it has already been dispatched. Events are dispatched one at a time
+func (r *Reconciler) receive(event polling.Event, source string) {
+	r.dispatchMu.Lock()
+	defer r.dispatchMu.Unlock()
+
+	if !r.record(event, source) {
+		return
+	}
+	if r.Handlers != nil {
+		r.Handlers.Dispatch(event)
+	}
+}
+
+// record remembers an event, returning false if it was already dispatched
+func (r *Reconciler) record(event polling.Event, source string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.currentTime()
+	r.prune(now)
+	key, ok := eventKey(event)
+	if !ok {
+		return true
+	}
+	if _, seen := r.seen[key]; seen {
+		r.stats.Duplicates++
+		return false
+	}
+	r.seen[key] = now
+	if source == SourceWebhook {
+		r.stats.Webhook++
+	} else {
+		r.stats.Backfilled++
+	}
+	return true
+}
+
+// eventKey returns the key new reports and activities are deduplicated by
+func eventKey(event polling.Event) (string, bool) {
+	switch {
+	case event.Type == polling.EventReportCreated && event.Report != nil && event.Report.ID != nil:
+		return "report/" + *event.Report.ID, true
+	case event.Type != polling.EventReportChanged && event.Activity != nil && event.Activity.ID != nil:
+		return "activity/" + *event.Activity.ID, true
+	}
+	return "", false
+}
+
+// prune forgets keys dispatched longer ago than the retention, at most once a minute
+func (r *Reconciler) prune(now time.Time) {
+	if now.Sub(r.lastPrune) < time.Minute {
+		return
+	}
+	r.lastPrune = now
+	retention := r.Retention
+	if retention <= 0 && r.Poller != nil {
+		retention = r.Poller.Window + r.Poller.ClockSkew + 2*r.Poller.Interval
+	}
+	if retention <= 0 {
+		retention = defaultRetention
+	}
+	for key, dispatchedAt := range r.seen {
+		if now.Sub(dispatchedAt) > retention {
+			delete(r.seen, key)
+		}
+	}
+}
+
+// currentTime returns the current time
+func (r *Reconciler) currentTime() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
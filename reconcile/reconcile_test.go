@@ -0,0 +1,156 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reconcile
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/polling"
+
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// recorder records the events dispatched to it
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) Dispatch(event polling.Event) {
+	switch {
+	case event.Activity != nil:
+		r.events = append(r.events, *event.Activity.ID)
+	case event.Report != nil:
+		r.events = append(r.events, "report "+*event.Report.ID)
+	default:
+		r.events = append(r.events, event.Type)
+	}
+}
+
+func newTestActivity(t *testing.T, id string) *h1.Activity {
+	var activity h1.Activity
+	data := `{"id":"` + id + `","type":"activity-comment","attributes":{"message":"Comment!"}}`
+	require.Nil(t, json.Unmarshal([]byte(data), &activity))
+	return &activity
+}
+
+func Test_Reconciler_Run(t *testing.T) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	attributes := fmt.Sprintf(`"title":"Report","state":"new","created_at":%q,"last_activity_at":%q`, now, now)
+	activity := func(id string) string {
+		return fmt.Sprintf(`{"id":%q,"type":"activity-comment","attributes":{"message":"Comment!","internal":false,"created_at":%q,"updated_at":%q}}`, id, now, now)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reports":
+			fmt.Fprintf(w, `{"data":[{"id":"1","type":"report","attributes":{%s}}],"links":{}}`, attributes)
+		case "/reports/1":
+			fmt.Fprintf(w, `{"data":{"id":"1","type":"report","attributes":{%s},"relationships":{"activities":{"data":[%s,%s]}}}}`,
+				attributes, activity("10"), activity("11"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := h1.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	downstream := &recorder{}
+	poller := polling.NewPoller(client, h1.ReportListFilter{}, time.Hour, 2*time.Hour, 10)
+	reconciler := New(poller, downstream)
+
+	// Receive the report and one of its activities by webhook before the sweep
+	report := &h1.Report{ID: h1.String("1")}
+	reconciler.Dispatch(polling.Event{Type: polling.EventReportCreated, Report: report})
+	reconciler.Dispatch(polling.NewActivityEvent(report, newTestActivity(t, "10")))
+	reconciler.Dispatch(polling.NewActivityEvent(report, newTestActivity(t, "10")))
+
+	// Stop after the first sweep
+	poller.OnPoll = func(polling.PollStats) {
+		go poller.Stop()
+	}
+	assert.Nil(t, reconciler.Run(context.Background()))
+
+	// Verify that each event was dispatched once, with the missed activity backfilled
+	assert.Equal(t, []string{"report 1", "10", "11"}, downstream.events)
+	assert.Equal(t, Stats{Webhook: 2, Backfilled: 1, Duplicates: 3}, reconciler.Stats())
+
+	// Verify that webhooks arriving after the sweep are still deduplicated
+	reconciler.Dispatch(polling.NewActivityEvent(report, newTestActivity(t, "11")))
+	assert.Equal(t, []string{"report 1", "10", "11"}, downstream.events)
+}
+
+func Test_Reconciler_passThrough(t *testing.T) {
+	downstream := &recorder{}
+	reconciler := New(polling.NewPoller(nil, h1.ReportListFilter{}, time.Minute, time.Minute, 0), downstream)
+
+	// Verify that errors and report changes are always dispatched
+	reconciler.receive(polling.Event{Type: polling.EventError}, SourcePoller)
+	reconciler.receive(polling.Event{Type: polling.EventError}, SourcePoller)
+	assert.Equal(t, []string{polling.EventError, polling.EventError}, downstream.events)
+	assert.Equal(t, Stats{}, reconciler.Stats())
+}
+
+func Test_Reconciler_prune(t *testing.T) {
+	now := time.Date(2016, 2, 2, 4, 5, 6, 0, time.UTC)
+	downstream := &recorder{}
+	reconciler := New(polling.NewPoller(nil, h1.ReportListFilter{}, time.Minute, 2*time.Minute, 0), downstream)
+	reconciler.now = func() time.Time { return now }
+	report := &h1.Report{ID: h1.String("1")}
+
+	// Verify that IDs are remembered within the retention, which defaults to the window, clock skew and two intervals
+	reconciler.Dispatch(polling.NewActivityEvent(report, newTestActivity(t, "10")))
+	now = now.Add(5 * time.Minute)
+	reconciler.Dispatch(polling.NewActivityEvent(report, newTestActivity(t, "10")))
+	assert.Equal(t, []string{"10"}, downstream.events)
+
+	// Verify that IDs are forgotten after the retention
+	now = now.Add(time.Minute + time.Second)
+	reconciler.Dispatch(polling.NewActivityEvent(report, newTestActivity(t, "10")))
+	assert.Equal(t, []string{"10", "10"}, downstream.events)
+}
+
+func Test_Reconciler_withoutPoller(t *testing.T) {
+	now := time.Date(2016, 2, 2, 4, 5, 6, 0, time.UTC)
+	downstream := &recorder{}
+	reconciler := New(nil, downstream)
+	reconciler.now = func() time.Time { return now }
+	report := &h1.Report{ID: h1.String("1")}
+
+	// Verify that webhooks are deduplicated for a day without a poller
+	reconciler.Dispatch(polling.NewActivityEvent(report, newTestActivity(t, "10")))
+	now = now.Add(23 * time.Hour)
+	reconciler.Dispatch(polling.NewActivityEvent(report, newTestActivity(t, "10")))
+	assert.Equal(t, []string{"10"}, downstream.events)
+	now = now.Add(time.Hour + time.Second)
+	reconciler.Dispatch(polling.NewActivityEvent(report, newTestActivity(t, "10")))
+	assert.Equal(t, []string{"10", "10"}, downstream.events)
+
+	// Verify that there's nothing to run
+	assert.EqualError(t, reconciler.Run(context.Background()), "reconcile: no poller to run")
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package trackersync
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+const defaultJiraIssueType = "Bug"
+
+// JiraTracker is a Tracker using the Jira REST API, version 2
+type JiraTracker struct {
+	BaseURL    string       // The Jira server, e.g. "https://jira.example.com/"
+	Client     *http.Client // The client to make requests with, defaults to http.DefaultClient
+	Username   string       // The user to authenticate as, requests are anonymous if empty
+	Password   string       // The user's password or API token
+	ProjectKey string       // The project to create issues in, e.g. "SEC"
+	IssueType  string       // The type of issue to create, defaults to "Bug"
+}
+
+// JiraError is returned when Jira responds with an error
+type JiraError struct {
+	StatusCode    int
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
+
+// Error describes the status code and every message Jira returned
+func (e *JiraError) Error() string {
+	messages := append([]string{}, e.ErrorMessages...)
+	for field, message := range e.Errors {
+		messages = append(messages, field+": "+message)
+	}
+	return fmt.Sprintf("jira: %d %s", e.StatusCode, strings.Join(messages, "; "))
+}
+
+// jiraIssue is an issue as returned by Jira
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		Status  *struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		Resolution *struct {
+			Name string `json:"name"`
+		} `json:"resolution"`
+	} `json:"fields"`
+}
+
+// jiraComment is a comment as returned by Jira
+type jiraComment struct {
+	ID     string `json:"id"`
+	Body   string `json:"body"`
+	Author struct {
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName"`
+	} `json:"author"`
+}
+
+// CreateIssue creates an issue in the project
+func (j *JiraTracker) CreateIssue(summary string, description string) (*Issue, error) {
+	issueType := j.IssueType
+	if issueType == "" {
+		issueType = defaultJiraIssueType
+	}
+	body := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]string{"key": j.ProjectKey},
+			"issuetype":   map[string]string{"name": issueType},
+			"summary":     summary,
+			"description": description,
+		},
+	}
+	var created jiraIssue
+	if err := j.do("POST", "rest/api/2/issue", body, &created); err != nil {
+		return nil, err
+	}
+	return j.Issue(created.Key)
+}
+
+// Issue returns an existing issue
+func (j *JiraTracker) Issue(key string) (*Issue, error) {
+	var issue jiraIssue
+	if err := j.do("GET", fmt.Sprintf("rest/api/2/issue/%s?fields=status,resolution", url.QueryEscape(key)), nil, &issue); err != nil {
+		return nil, err
+	}
+	return j.newIssue(issue), nil
+}
+
+// FindIssue returns the oldest issue in the project whose summary starts with a prefix. Jira's text search
+// ignores punctuation, so it only narrows the issues down and the prefix is checked on each result
+func (j *JiraTracker) FindIssue(summaryPrefix string) (*Issue, error) {
+	words := strings.FieldsFunc(summaryPrefix, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	jql := fmt.Sprintf("project = %s AND summary ~ %s ORDER BY created ASC", jqlQuote(j.ProjectKey), jqlQuote(jqlQuote(strings.Join(words, " "))))
+	for start := 0; ; {
+		var page struct {
+			StartAt int         `json:"startAt"`
+			Total   int         `json:"total"`
+			Issues  []jiraIssue `json:"issues"`
+		}
+		path := fmt.Sprintf("rest/api/2/search?jql=%s&fields=summary,status,resolution&startAt=%d", url.QueryEscape(jql), start)
+		if err := j.do("GET", path, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, issue := range page.Issues {
+			if strings.HasPrefix(issue.Fields.Summary, summaryPrefix) {
+				return j.newIssue(issue), nil
+			}
+		}
+		start += len(page.Issues)
+		if len(page.Issues) == 0 || start >= page.Total {
+			return nil, nil
+		}
+	}
+}
+
+// jqlQuote quotes a string for use in a JQL query
+func jqlQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// newIssue converts a Jira issue
+func (j *JiraTracker) newIssue(issue jiraIssue) *Issue {
+	result := &Issue{
+		Key: issue.Key,
+		URL: j.resolve("browse/" + url.QueryEscape(issue.Key)),
+	}
+	if issue.Fields.Status != nil {
+		result.Status = issue.Fields.Status.Name
+	}
+	if issue.Fields.Resolution != nil {
+		result.Resolution = issue.Fields.Resolution.Name
+	}
+	return result
+}
+
+// Comments returns every comment on an issue, following pagination
+func (j *JiraTracker) Comments(key string) ([]Comment, error) {
+	var comments []Comment
+	for {
+		var page struct {
+			StartAt  int           `json:"startAt"`
+			Total    int           `json:"total"`
+			Comments []jiraComment `json:"comments"`
+		}
+		path := fmt.Sprintf("rest/api/2/issue/%s/comment?startAt=%d", url.QueryEscape(key), len(comments))
+		if err := j.do("GET", path, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, comment := range page.Comments {
+			comments = append(comments, newComment(comment))
+		}
+		if len(page.Comments) == 0 || len(comments) >= page.Total {
+			return comments, nil
+		}
+	}
+}
+
+// AddComment adds a comment to an issue
+func (j *JiraTracker) AddComment(key string, body string) (*Comment, error) {
+	var comment jiraComment
+	if err := j.do("POST", fmt.Sprintf("rest/api/2/issue/%s/comment", url.QueryEscape(key)), map[string]string{"body": body}, &comment); err != nil {
+		return nil, err
+	}
+	result := newComment(comment)
+	return &result, nil
+}
+
+// newComment converts a Jira comment
+func newComment(comment jiraComment) Comment {
+	author := comment.Author.DisplayName
+	if author == "" {
+		author = comment.Author.Name
+	}
+	return Comment{
+		ID:     comment.ID,
+		Author: author,
+		Body:   comment.Body,
+	}
+}
+
+// resolve returns the URL of a path relative to the base URL
+func (j *JiraTracker) resolve(path string) string {
+	return strings.TrimSuffix(j.BaseURL, "/") + "/" + path
+}
+
+// do makes a request, encoding body and decoding the response into v as JSON
+func (j *JiraTracker) do(method string, path string, body interface{}, v interface{}) error {
+	var buf io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		buf = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, j.resolve(path), buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if j.Username != "" {
+		req.SetBasicAuth(j.Username, j.Password)
+	}
+
+	client := j.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		jiraErr := &JiraError{StatusCode: resp.StatusCode}
+		data, err := ioutil.ReadAll(resp.Body)
+		if err == nil {
+			// Ignore errors here so we always pass out a JiraError
+			json.Unmarshal(data, jiraErr)
+		}
+		return jiraErr
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package trackersync
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeJira is an in-memory Jira server returning comments and search results in pages of two. Searches return every issue, oldest first
+type fakeJira struct {
+	mu       sync.Mutex
+	issues   map[string]map[string]interface{}
+	comments map[string][]jiraComment
+	searches []string // The JQL of each search
+}
+
+func newFakeJira() (*fakeJira, *httptest.Server) {
+	jira := &fakeJira{
+		issues:   map[string]map[string]interface{}{},
+		comments: map[string][]jiraComment{},
+	}
+	return jira, httptest.NewServer(jira)
+}
+
+func (f *fakeJira) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if user, pass, ok := r.BasicAuth(); !ok || user != "bot" || pass != "token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errorMessages":["not logged in"]}`)
+		return
+	}
+	if r.Method == "GET" && r.URL.Path == "/rest/api/2/search" {
+		f.searches = append(f.searches, r.URL.Query().Get("jql"))
+		start, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		var issues []string
+		for i := start + 1; i <= start+2 && i <= len(f.issues); i++ {
+			key := fmt.Sprintf("SEC-%d", i)
+			issues = append(issues, fmt.Sprintf(`{"key":%q,"fields":{"summary":%q,"status":{"name":"To Do"},"resolution":null}}`, key, f.issues[key]["summary"]))
+		}
+		fmt.Fprintf(w, `{"startAt":%d,"maxResults":2,"total":%d,"issues":[%s]}`, start, len(f.issues), strings.Join(issues, ","))
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue"), "/")
+	switch {
+	case r.Method == "POST" && len(parts) == 1:
+		var body struct {
+			Fields map[string]interface{} `json:"fields"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		key := fmt.Sprintf("SEC-%d", len(f.issues)+1)
+		f.issues[key] = body.Fields
+		fmt.Fprintf(w, `{"id":"1","key":%q}`, key)
+	case r.Method == "GET" && len(parts) == 2:
+		if _, ok := f.issues[parts[1]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errorMessages":["Issue does not exist"],"errors":{}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"key":%q,"fields":{"status":{"name":"Done"},"resolution":{"name":"Fixed"}}}`, parts[1])
+	case r.Method == "GET" && len(parts) == 3:
+		comments := f.comments[parts[1]]
+		start, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		end := start + 2
+		if end > len(comments) {
+			end = len(comments)
+		}
+		page, _ := json.Marshal(comments[start:end])
+		fmt.Fprintf(w, `{"startAt":%d,"maxResults":2,"total":%d,"comments":%s}`, start, len(comments), page)
+	case r.Method == "POST" && len(parts) == 3:
+		var comment jiraComment
+		json.NewDecoder(r.Body).Decode(&comment)
+		comment.ID = strconv.Itoa(len(f.comments[parts[1]]) + 1)
+		comment.Author.DisplayName = "Bot"
+		f.comments[parts[1]] = append(f.comments[parts[1]], comment)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(comment)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errors":{"path":"unexpected request"}}`)
+	}
+}
+
+func newTestJiraTracker(server *httptest.Server) *JiraTracker {
+	return &JiraTracker{
+		BaseURL:    server.URL + "/",
+		Username:   "bot",
+		Password:   "token",
+		ProjectKey: "SEC",
+	}
+}
+
+func Test_JiraTracker_CreateIssue(t *testing.T) {
+	jira, server := newFakeJira()
+	defer server.Close()
+	tracker := newTestJiraTracker(server)
+
+	issue, err := tracker.CreateIssue("Summary", "Description")
+	require.Nil(t, err)
+	assert.Equal(t, &Issue{
+		Key:        "SEC-1",
+		URL:        server.URL + "/browse/SEC-1",
+		Status:     "Done",
+		Resolution: "Fixed",
+	}, issue)
+	assert.Equal(t, map[string]interface{}{
+		"project":     map[string]interface{}{"key": "SEC"},
+		"issuetype":   map[string]interface{}{"name": "Bug"},
+		"summary":     "Summary",
+		"description": "Description",
+	}, jira.issues["SEC-1"])
+
+	tracker.IssueType = "Task"
+	_, err = tracker.CreateIssue("Summary", "Description")
+	require.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "Task"}, jira.issues["SEC-2"]["issuetype"])
+}
+
+func Test_JiraTracker_Issue(t *testing.T) {
+	_, server := newFakeJira()
+	defer server.Close()
+	tracker := newTestJiraTracker(server)
+
+	_, err := tracker.Issue("SEC-404")
+	assert.Equal(t, &JiraError{
+		StatusCode:    http.StatusNotFound,
+		ErrorMessages: []string{"Issue does not exist"},
+		Errors:        map[string]string{},
+	}, err)
+	assert.Equal(t, "jira: 404 Issue does not exist", err.Error())
+
+	tracker.Password = "wrong"
+	_, err = tracker.CreateIssue("Summary", "Description")
+	assert.Equal(t, "jira: 401 not logged in", err.Error())
+}
+
+func Test_JiraTracker_FindIssue(t *testing.T) {
+	jira, server := newFakeJira()
+	defer server.Close()
+	tracker := newTestJiraTracker(server)
+
+	issue, err := tracker.FindIssue("[HackerOne #5]")
+	require.Nil(t, err)
+	assert.Nil(t, issue)
+	assert.Equal(t, []string{`project = "SEC" AND summary ~ "\"HackerOne 5\"" ORDER BY created ASC`}, jira.searches)
+
+	// Verify that the prefix is checked on every page of results
+	for _, summary := range []string{"[HackerOne #50] Other", "[HackerOne #6] Other", "Mentions [HackerOne #5]", "[HackerOne #5] XSS", "[HackerOne #5] Again"} {
+		_, err := tracker.CreateIssue(summary, "Description")
+		require.Nil(t, err)
+	}
+	issue, err = tracker.FindIssue("[HackerOne #5]")
+	require.Nil(t, err)
+	assert.Equal(t, &Issue{
+		Key:    "SEC-4",
+		URL:    server.URL + "/browse/SEC-4",
+		Status: "To Do",
+	}, issue)
+
+	tracker.Password = "wrong"
+	_, err = tracker.FindIssue("[HackerOne #5]")
+	assert.Equal(t, "jira: 401 not logged in", err.Error())
+}
+
+func Test_JiraTracker_Comments(t *testing.T) {
+	_, server := newFakeJira()
+	defer server.Close()
+	tracker := newTestJiraTracker(server)
+
+	comments, err := tracker.Comments("SEC-1")
+	require.Nil(t, err)
+	assert.Empty(t, comments)
+
+	for i := 1; i <= 5; i++ {
+		comment, err := tracker.AddComment("SEC-1", fmt.Sprintf("Comment %d", i))
+		require.Nil(t, err)
+		assert.Equal(t, &Comment{ID: strconv.Itoa(i), Author: "Bot", Body: fmt.Sprintf("Comment %d", i)}, comment)
+	}
+
+	comments, err = tracker.Comments("SEC-1")
+	require.Nil(t, err)
+	require.Len(t, comments, 5)
+	for i, comment := range comments {
+		assert.Equal(t, fmt.Sprintf("Comment %d", i+1), comment.Body)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package trackersync
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// reportURLPrefix is where reports can be viewed on HackerOne
+const reportURLPrefix = "https://hackerone.com/reports/"
+
+// Comments are mirrored with a marker naming where they came from so that syncing is stateless: a comment
+// that is already mirrored, or that was itself mirrored from the other side, is never copied again.
+var (
+	hackeroneMarker = regexp.MustCompile(`^\[HackerOne activity ([^\]]+)\]`)
+	trackerMarker   = regexp.MustCompile(`^\[Tracker comment ([^\]]+)\]`)
+)
+
+// Syncer mirrors HackerOne reports into a Tracker
+type Syncer struct {
+	Client  *h1.Client
+	Tracker Tracker
+}
+
+// NewSyncer creates a Syncer
+func NewSyncer(client *h1.Client, tracker Tracker) *Syncer {
+	return &Syncer{
+		Client:  client,
+		Tracker: tracker,
+	}
+}
+
+// Sync mirrors a report into the tracker. Triaged reports without an issue tracker reference get an issue,
+// which is written back to HackerOne as the report's reference. An issue already created for the report is
+// found by its summary and reused, so one whose reference couldn't be written back isn't created twice. Reports with a reference have their comments
+// synced in both directions, with tracker comments added to the report as internal comments. The report should
+// be fetched with Report.Get so that it includes its activities. It returns the report's issue, or nil if
+// the report isn't tracked.
+func (s *Syncer) Sync(report *h1.Report) (*Issue, error) {
+	issue, err := s.issue(report)
+	if issue == nil || err != nil {
+		return issue, err
+	}
+	if err := s.syncComments(report, issue); err != nil {
+		return issue, err
+	}
+	return issue, nil
+}
+
+// issue returns the report's issue, creating it if the report needs one
+func (s *Syncer) issue(report *h1.Report) (*Issue, error) {
+	if report.IssueTrackerReferenceID != nil && *report.IssueTrackerReferenceID != "" {
+		return s.Tracker.Issue(*report.IssueTrackerReferenceID)
+	}
+	if report.State == nil || *report.State != h1.ReportStateTriaged {
+		return nil, nil
+	}
+
+	issue, err := s.Tracker.FindIssue(summaryPrefix(report))
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		issue, err = s.Tracker.CreateIssue(Summary(report), Description(report))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, _, err := s.Client.Report.UpdateIssueTrackerReferenceID(*report.ID, issue.Key, "Tracked in "+issue.URL); err != nil {
+		return issue, err
+	}
+	report.IssueTrackerReferenceID = &issue.Key
+	report.IssueTrackerReferenceURL = &issue.URL
+	return issue, nil
+}
+
+// syncComments copies comments that haven't been mirrored yet between the report and its issue
+func (s *Syncer) syncComments(report *h1.Report, issue *Issue) error {
+	comments, err := s.Tracker.Comments(issue.Key)
+	if err != nil {
+		return err
+	}
+
+	// Find what has already been mirrored in each direction
+	inTracker := map[string]bool{}
+	for _, comment := range comments {
+		if match := hackeroneMarker.FindStringSubmatch(comment.Body); match != nil {
+			inTracker[match[1]] = true
+		}
+	}
+	inReport := map[string]bool{}
+	for _, activity := range report.Activities {
+		if activity.Message == nil {
+			continue
+		}
+		if match := trackerMarker.FindStringSubmatch(*activity.Message); match != nil {
+			inReport[match[1]] = true
+		}
+	}
+
+	for _, activity := range report.Activities {
+		if activity.Type == nil || *activity.Type != h1.ActivityCommentType || activity.Message == nil {
+			continue
+		}
+		if inTracker[*activity.ID] || trackerMarker.MatchString(*activity.Message) {
+			continue
+		}
+		body := fmt.Sprintf("[HackerOne activity %s] %s: %s", *activity.ID, actorName(&activity), *activity.Message)
+		if _, err := s.Tracker.AddComment(issue.Key, body); err != nil {
+			return err
+		}
+	}
+
+	for _, comment := range comments {
+		if inReport[comment.ID] || hackeroneMarker.MatchString(comment.Body) {
+			continue
+		}
+		message := fmt.Sprintf("[Tracker comment %s] %s: %s", comment.ID, comment.Author, comment.Body)
+		if _, _, err := s.Client.Report.CreateComment(*report.ID, message, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Summary returns the summary of the issue created for a report
+func Summary(report *h1.Report) string {
+	title := ""
+	if report.Title != nil {
+		title = *report.Title
+	}
+	return summaryPrefix(report) + " " + title
+}
+
+// summaryPrefix returns the start of the summary of a report's issue, which identifies the report
+func summaryPrefix(report *h1.Report) string {
+	return fmt.Sprintf("[HackerOne #%s]", *report.ID)
+}
+
+// Description returns the description of the issue created for a report
+func Description(report *h1.Report) string {
+	var parts []string
+	if report.VulnerabilityInformation != nil && *report.VulnerabilityInformation != "" {
+		parts = append(parts, *report.VulnerabilityInformation)
+	}
+	parts = append(parts, "Reported on HackerOne: "+reportURLPrefix+*report.ID)
+	return strings.Join(parts, "\n\n")
+}
+
+// actorName returns the username or handle of whoever performed an activity
+func actorName(activity *h1.Activity) string {
+	if len(activity.RawActor) == 0 || string(activity.RawActor) == "null" {
+		return "unknown"
+	}
+	switch actor := activity.Actor().(type) {
+	case *h1.User:
+		if actor.Username != nil {
+			return *actor.Username
+		}
+	case *h1.Program:
+		if actor.Handle != nil {
+			return *actor.Handle
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package trackersync
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeHackerOne records the requests made to it
+type fakeHackerOne struct {
+	mu       sync.Mutex
+	requests []string
+	fail     bool
+}
+
+func (f *fakeHackerOne) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path+" "+string(body))
+	if f.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprint(w, `{"id":"99","type":"activity-comment","attributes":{}}`)
+}
+
+func newTestSyncer(t *testing.T) (*Syncer, *fakeHackerOne, *fakeJira, func()) {
+	hackerone := &fakeHackerOne{}
+	h1Server := httptest.NewServer(hackerone)
+	jira, jiraServer := newFakeJira()
+
+	client := h1.NewClient(nil)
+	client.BaseURL, _ = url.Parse(h1Server.URL + "/")
+	syncer := NewSyncer(client, newTestJiraTracker(jiraServer))
+	return syncer, hackerone, jira, func() {
+		h1Server.Close()
+		jiraServer.Close()
+	}
+}
+
+func newTestReport(t *testing.T, state string, activities ...string) *h1.Report {
+	var report h1.Report
+	raw := fmt.Sprintf(`{"id":"5","type":"report","attributes":{"title":"XSS in search","state":%q,"vulnerability_information":"Details"},"relationships":{"activities":{"data":[%s]}}}`,
+		state, strings.Join(activities, ","))
+	require.Nil(t, json.Unmarshal([]byte(raw), &report))
+	return &report
+}
+
+func newTestComment(id string, username string, message string) string {
+	return fmt.Sprintf(`{"id":%q,"type":"activity-comment","attributes":{"message":%q,"internal":false},"relationships":{"actor":{"data":{"id":"1","type":"user","attributes":{"username":%q}}}}}`,
+		id, message, username)
+}
+
+func Test_Syncer_Sync(t *testing.T) {
+	syncer, hackerone, jira, done := newTestSyncer(t)
+	defer done()
+
+	// Reports which aren't triaged aren't tracked
+	issue, err := syncer.Sync(newTestReport(t, h1.ReportStateNew, newTestComment("10", "alice", "Looks valid")))
+	require.Nil(t, err)
+	assert.Nil(t, issue)
+	assert.Empty(t, jira.issues)
+	assert.Empty(t, hackerone.requests)
+
+	// Triaged reports get an issue, which is written back, and their comments are mirrored
+	report := newTestReport(t, h1.ReportStateTriaged,
+		newTestComment("10", "alice", "Looks valid"),
+		`{"id":"11","type":"activity-bug-triaged","attributes":{"message":"Triaged"}}`,
+	)
+	issue, err = syncer.Sync(report)
+	require.Nil(t, err)
+	require.NotNil(t, issue)
+	assert.Equal(t, "SEC-1", issue.Key)
+	assert.Equal(t, "SEC-1", *report.IssueTrackerReferenceID)
+	assert.Equal(t, issue.URL, *report.IssueTrackerReferenceURL)
+	assert.Equal(t, "[HackerOne #5] XSS in search", jira.issues["SEC-1"]["summary"])
+	assert.Equal(t, "Details\n\nReported on HackerOne: https://hackerone.com/reports/5", jira.issues["SEC-1"]["description"])
+	assert.Equal(t, []string{
+		`POST /reports/5/issue_tracker_reference_id {"data":{"type":"issue-tracker-reference-id","attributes":{"reference":"SEC-1","message":"Tracked in ` + issue.URL + `"}}}` + "\n",
+	}, hackerone.requests)
+	require.Len(t, jira.comments["SEC-1"], 1)
+	assert.Equal(t, "[HackerOne activity 10] alice: Looks valid", jira.comments["SEC-1"][0].Body)
+
+	// Tracker comments are added to the report as internal comments
+	jira.comments["SEC-1"] = append(jira.comments["SEC-1"], jiraComment{ID: "2", Body: "Fixed in abc123"})
+	jira.comments["SEC-1"][1].Author.DisplayName = "Dev"
+	hackerone.requests = nil
+	_, err = syncer.Sync(report)
+	require.Nil(t, err)
+	assert.Equal(t, []string{
+		`POST /reports/5/activities {"data":{"type":"activity-comment","attributes":{"message":"[Tracker comment 2] Dev: Fixed in abc123","internal":true}}}` + "\n",
+	}, hackerone.requests)
+	assert.Len(t, jira.comments["SEC-1"], 2)
+
+	// Once mirrored, nothing is copied again in either direction
+	report = newTestReport(t, h1.ReportStateTriaged,
+		newTestComment("10", "alice", "Looks valid"),
+		newTestComment("12", "api-bot", "[Tracker comment 2] Dev: Fixed in abc123"),
+	)
+	report.IssueTrackerReferenceID = &issue.Key
+	hackerone.requests = nil
+	_, err = syncer.Sync(report)
+	require.Nil(t, err)
+	assert.Empty(t, hackerone.requests)
+	assert.Len(t, jira.comments["SEC-1"], 2)
+}
+
+func Test_Syncer_Sync_Errors(t *testing.T) {
+	syncer, hackerone, jira, done := newTestSyncer(t)
+	defer done()
+
+	// The issue is returned even if it can't be written back
+	hackerone.fail = true
+	report := newTestReport(t, h1.ReportStateTriaged)
+	issue, err := syncer.Sync(report)
+	assert.NotNil(t, err)
+	require.NotNil(t, issue)
+	assert.Equal(t, "SEC-1", issue.Key)
+	assert.Nil(t, report.IssueTrackerReferenceID)
+	assert.Len(t, jira.issues, 1)
+
+	// Syncing again finds the issue rather than creating another, and writes it back
+	hackerone.fail = false
+	hackerone.requests = nil
+	issue, err = syncer.Sync(report)
+	require.Nil(t, err)
+	assert.Equal(t, "SEC-1", issue.Key)
+	assert.Equal(t, "SEC-1", *report.IssueTrackerReferenceID)
+	assert.Len(t, jira.issues, 1)
+	require.NotEmpty(t, hackerone.requests)
+	assert.Contains(t, hackerone.requests[0], `POST /reports/5/issue_tracker_reference_id {"data":{"type":"issue-tracker-reference-id","attributes":{"reference":"SEC-1"`)
+
+	// Missing issues are reported
+	missing := "SEC-404"
+	report.IssueTrackerReferenceID = &missing
+	_, err = syncer.Sync(report)
+	assert.IsType(t, &JiraError{}, err)
+}
+
+func Test_actorName(t *testing.T) {
+	report := newTestReport(t, h1.ReportStateTriaged,
+		newTestComment("1", "alice", ""),
+		`{"id":"2","type":"activity-comment","relationships":{"actor":{"data":{"id":"1","type":"program","attributes":{"handle":"security"}}}}}`,
+		`{"id":"3","type":"activity-comment"}`,
+		`{"id":"4","type":"activity-comment","relationships":{"actor":{"data":null}}}`,
+	)
+	var names []string
+	for _, activity := range report.Activities {
+		names = append(names, actorName(&activity))
+	}
+	assert.Equal(t, []string{"alice", "security", "unknown", "unknown"}, names)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package trackersync mirrors triaged HackerOne reports into an issue tracker and keeps their comments in sync.
+package trackersync
+
+// Issue is an issue in a tracker
+type Issue struct {
+	Key        string // The tracker's identifier for the issue, e.g. "SEC-123"
+	URL        string // Where the issue can be viewed
+	Status     string // The issue's workflow status, e.g. "In Progress"
+	Resolution string // How the issue was resolved, e.g. "Fixed", or empty if it's unresolved
+}
+
+// Comment is a comment on a tracker issue
+type Comment struct {
+	ID     string
+	Author string
+	Body   string
+}
+
+// Tracker is an issue tracker reports can be mirrored into
+type Tracker interface {
+	// CreateIssue creates an issue
+	CreateIssue(summary string, description string) (*Issue, error)
+	// Issue returns an existing issue, including its status
+	Issue(key string) (*Issue, error)
+	// FindIssue returns the oldest issue whose summary starts with a prefix, or nil if there isn't one
+	FindIssue(summaryPrefix string) (*Issue, error)
+	// Comments returns every comment on an issue, oldest first
+	Comments(key string) ([]Comment, error)
+	// AddComment adds a comment to an issue
+	AddComment(key string, body string) (*Comment, error)
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package webhook receives HackerOne webhooks, verifying their signatures and dispatching them as polling.Events.
+package webhook
+
+import (
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/polling"
+
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers sent with each webhook
+const (
+	SignatureHeader string = "X-H1-Signature" // The HMAC-SHA256 of the body using the shared secret, as "sha256=<hex>"
+	EventHeader     string = "X-H1-Event"     // The name of the event, such as report_created
+	DeliveryHeader  string = "X-H1-Delivery"  // The unique ID of the delivery, which isn't signed
+)
+
+// EventReportCreated is the name of the webhook event sent when a report is submitted
+const EventReportCreated string = "report_created"
+
+const (
+	defaultMaxAge       = 24 * time.Hour
+	defaultMaxBodyBytes = 1 << 20
+	signaturePrefix     = "sha256="
+)
+
+// payload is the body of a webhook
+type payload struct {
+	Data struct {
+		Report   json.RawMessage `json:"report"`
+		Activity json.RawMessage `json:"activity"`
+	} `json:"data"`
+}
+
+// decodePayload decodes the report and activity, if any, of a webhook. The activity is decoded as one of the report's activities so that,
+// like activities from a Poller, its Report method returns the report
+func decodePayload(body []byte) (*h1.Report, *h1.Activity, error) {
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, nil, err
+	}
+	var resource map[string]json.RawMessage
+	if err := json.Unmarshal(p.Data.Report, &resource); err != nil {
+		return nil, nil, err
+	}
+	if resource == nil {
+		return nil, nil, errors.New("webhook: payload has no report")
+	}
+
+	hasActivity := len(p.Data.Activity) > 0 && string(p.Data.Activity) != "null"
+	if hasActivity {
+		var relationships map[string]json.RawMessage
+		if raw, ok := resource["relationships"]; ok {
+			if err := json.Unmarshal(raw, &relationships); err != nil {
+				return nil, nil, err
+			}
+		}
+		if relationships == nil {
+			relationships = make(map[string]json.RawMessage)
+		}
+		var activities struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		if raw, ok := relationships["activities"]; ok {
+			if err := json.Unmarshal(raw, &activities); err != nil {
+				return nil, nil, err
+			}
+		}
+		activities.Data = append(activities.Data, p.Data.Activity)
+		var err error
+		if relationships["activities"], err = json.Marshal(activities); err != nil {
+			return nil, nil, err
+		}
+		if resource["relationships"], err = json.Marshal(relationships); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, nil, err
+	}
+	var report h1.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, nil, err
+	}
+	if report.ID == nil {
+		return nil, nil, errors.New("webhook: report has no ID")
+	}
+	if !hasActivity {
+		return &report, nil, nil
+	}
+	return &report, &report.Activities[len(report.Activities)-1], nil
+}
+
+// Handler is an http.Handler which receives webhooks and dispatches them to Handlers, such as polling.Handlers.
+//
+// Requests are rejected unless their signature matches the secret, so a Handler without a secret rejects everything. To protect against replays, each signed body is only accepted once
+// and events which happened more than MaxAge ago are rejected. The delivery ID isn't signed, so it isn't used for replay protection.
+// Handlers are called before the response is written, so they should be quick.
+type Handler struct {
+	Secret       []byte             // The secret shared with HackerOne
+	Handlers     polling.Dispatcher // Where events are dispatched
+	MaxAge       time.Duration      // How old an event may be before it's rejected, defaults to a day
+	MaxBodyBytes int64              // The largest body accepted, defaults to 1MB
+
+	mu       sync.Mutex
+	accepted map[string]time.Time // When each signature was accepted
+	now      func() time.Time     // Used to fake the time in tests
+}
+
+// NewHandler returns a Handler which dispatches events to handlers
+func NewHandler(secret []byte, handlers polling.Dispatcher) *Handler {
+	return &Handler{
+		Secret:       secret,
+		Handlers:     handlers,
+		MaxAge:       defaultMaxAge,
+		MaxBodyBytes: defaultMaxBodyBytes,
+	}
+}
+
+// Sign returns the signature HackerOne sends for a body
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns whether a signature is valid for a body. Signatures are never valid for an empty secret
+func Verify(secret []byte, body []byte, signature string) bool {
+	if len(secret) == 0 || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	actual, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(actual, mac.Sum(nil))
+}
+
+// ServeHTTP receives a webhook
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Read the body, refusing anything too large
+	maxBodyBytes := h.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = defaultMaxBodyBytes
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	if err != nil {
+		http.Error(w, "unable to read body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > maxBodyBytes {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// Check the signature before looking at anything else
+	signature := r.Header.Get(SignatureHeader)
+	if !Verify(h.Secret, body, signature) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	report, activity, err := decodePayload(body)
+	if err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	// Acknowledge webhooks we don't have an event for without checking their age
+	event, ok := newEvent(r.Header.Get(EventHeader), report, activity)
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// Reject old events and bodies we've already accepted
+	if !h.fresh(event) {
+		http.Error(w, "event too old", http.StatusBadRequest)
+		return
+	}
+	if !h.accept(strings.ToLower(signature)) {
+		http.Error(w, "delivery already received", http.StatusConflict)
+		return
+	}
+
+	if h.Handlers != nil {
+		h.Handlers.Dispatch(event)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newEvent returns the event for a webhook. Webhooks without an activity only have an event when they are for a new report
+func newEvent(name string, report *h1.Report, activity *h1.Activity) (polling.Event, bool) {
+	if activity != nil {
+		return polling.NewActivityEvent(report, activity), true
+	}
+	if name == EventReportCreated {
+		return polling.Event{Type: polling.EventReportCreated, Report: report}, true
+	}
+	return polling.Event{}, false
+}
+
+// fresh returns whether the event happened within MaxAge, using the activity's time or, for new reports, the report's
+func (h *Handler) fresh(event polling.Event) bool {
+	var at *h1.Timestamp
+	if event.Activity != nil {
+		at = event.Activity.UpdatedAt
+		if at == nil {
+			at = event.Activity.CreatedAt
+		}
+	} else {
+		at = event.Report.CreatedAt
+	}
+	if at == nil {
+		return false
+	}
+	return h.currentTime().Sub(at.Time) <= h.maxAge()
+}
+
+// accept records the signature of a body, returning false if it was already accepted. Signatures are forgotten after MaxAge as older events are rejected anyway
+func (h *Handler) accept(signature string) bool {
+	now := h.currentTime()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.accepted == nil {
+		h.accepted = make(map[string]time.Time)
+	}
+	for key, acceptedAt := range h.accepted {
+		if now.Sub(acceptedAt) > h.maxAge() {
+			delete(h.accepted, key)
+		}
+	}
+	if _, seen := h.accepted[signature]; seen {
+		return false
+	}
+	h.accepted[signature] = now
+	return true
+}
+
+// maxAge returns MaxAge or its default
+func (h *Handler) maxAge() time.Duration {
+	if h.MaxAge <= 0 {
+		return defaultMaxAge
+	}
+	return h.MaxAge
+}
+
+// currentTime returns the current time
+func (h *Handler) currentTime() time.Time {
+	if h.now != nil {
+		return h.now()
+	}
+	return time.Now()
+}
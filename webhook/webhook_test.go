@@ -0,0 +1,169 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package webhook
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/hackeroni/h1"
+	"github.com/uber-go/hackeroni/polling"
+
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("shared-secret")
+
+func newTestHandler() (*Handler, *[]polling.Event) {
+	var events []polling.Event
+	var handlers polling.Handlers
+	for _, eventType := range []string{polling.EventReportCreated, polling.EventCommentAdded, polling.EventStateChanged} {
+		handlers.On(eventType, func(event polling.Event) {
+			events = append(events, event)
+		})
+	}
+	handler := NewHandler(testSecret, &handlers)
+	handler.now = func() time.Time {
+		return h1.NewTimestamp("2016-02-02T06:00:00Z").Time
+	}
+	return handler, &events
+}
+
+func deliver(handler http.Handler, name string, body []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	req.Header.Set(EventHeader, name)
+	req.Header.Set(DeliveryHeader, "delivery")
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	return resp
+}
+
+func loadPayload(t *testing.T, file string) []byte {
+	body, err := ioutil.ReadFile("tests/" + file)
+	require.Nil(t, err)
+	return body
+}
+
+func Test_Verify(t *testing.T) {
+	body := []byte(`{"data":{}}`)
+	signature := Sign(testSecret, body)
+	assert.True(t, Verify(testSecret, body, signature))
+	assert.False(t, Verify([]byte("other-secret"), body, signature))
+	assert.False(t, Verify(testSecret, []byte(`{"data":[]}`), signature))
+	assert.False(t, Verify(testSecret, body, signature[len("sha256="):]))
+	assert.False(t, Verify(testSecret, body, "sha256=zz"))
+	assert.False(t, Verify(testSecret, body, ""))
+
+	// Verify that an empty secret never verifies, even with its own signature
+	assert.False(t, Verify(nil, body, Sign(nil, body)))
+	assert.False(t, Verify([]byte{}, body, Sign([]byte{}, body)))
+}
+
+func Test_Handler_ServeHTTP(t *testing.T) {
+	handler, events := newTestHandler()
+
+	// Verify that a new report is dispatched
+	body := loadPayload(t, "report_created.json")
+	resp := deliver(handler, EventReportCreated, body, Sign(testSecret, body))
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	require.Len(t, *events, 1)
+	assert.Equal(t, polling.EventReportCreated, (*events)[0].Type)
+	assert.Equal(t, "1337", *(*events)[0].Report.ID)
+	assert.Equal(t, "api-example", *(*events)[0].Report.Reporter.Username)
+
+	// Verify that an activity is dispatched with its typed event
+	body = loadPayload(t, "report_comment_created.json")
+	resp = deliver(handler, "report_comment_created", body, Sign(testSecret, body))
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	require.Len(t, *events, 2)
+	assert.Equal(t, polling.EventCommentAdded, (*events)[1].Type)
+	assert.Equal(t, "1338", *(*events)[1].Activity.ID)
+	assert.Equal(t, "Comment!", *(*events)[1].Activity.Message)
+	assert.Equal(t, h1.ReportStateTriaged, *(*events)[1].Report.State)
+
+	// Verify that the activity is linked to its report, as activities from a Poller are
+	require.NotNil(t, (*events)[1].Activity.Report())
+	assert.True(t, (*events)[1].Report == (*events)[1].Activity.Report())
+	assert.Equal(t, "1337", *(*events)[1].Activity.Report().ID)
+
+	// Verify that replaying a webhook is rejected, even with a new delivery ID
+	resp = deliver(handler, "report_comment_created", body, Sign(testSecret, body))
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Len(t, *events, 2)
+}
+
+func Test_Handler_ServeHTTP_rejected(t *testing.T) {
+	handler, events := newTestHandler()
+	body := loadPayload(t, "report_comment_created.json")
+
+	// Verify that only POST is accepted
+	req := httptest.NewRequest("GET", "/", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+
+	// Verify that missing and invalid signatures are rejected
+	assert.Equal(t, http.StatusUnauthorized, deliver(handler, "report_comment_created", body, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, deliver(handler, "report_comment_created", body, Sign([]byte("other-secret"), body)).Code)
+
+	// Verify that invalid payloads are rejected
+	invalid := []byte(`{"data":{}}`)
+	assert.Equal(t, http.StatusBadRequest, deliver(handler, "report_comment_created", invalid, Sign(testSecret, invalid)).Code)
+	invalid = []byte(`{`)
+	assert.Equal(t, http.StatusBadRequest, deliver(handler, "report_comment_created", invalid, Sign(testSecret, invalid)).Code)
+
+	// Verify that large bodies are rejected
+	handler.MaxBodyBytes = 10
+	assert.Equal(t, http.StatusRequestEntityTooLarge, deliver(handler, "report_comment_created", body, Sign(testSecret, body)).Code)
+	handler.MaxBodyBytes = 0
+
+	// Verify that old events are rejected
+	handler.MaxAge = time.Minute
+	assert.Equal(t, http.StatusBadRequest, deliver(handler, "report_comment_created", body, Sign(testSecret, body)).Code)
+
+	// Verify that unknown events without activities are accepted but not dispatched, however old the report is
+	body = loadPayload(t, "report_created.json")
+	assert.Equal(t, http.StatusNoContent, deliver(handler, "report_unknown", body, Sign(testSecret, body)).Code)
+
+	// Verify that a handler without a secret rejects everything
+	handler.Secret = nil
+	assert.Equal(t, http.StatusUnauthorized, deliver(handler, EventReportCreated, body, Sign(nil, body)).Code)
+
+	assert.Len(t, *events, 0)
+}
+
+func Test_Handler_ServeHTTP_activityAge(t *testing.T) {
+	handler, events := newTestHandler()
+
+	// Verify that an activity's age is used rather than its report's, which is older
+	handler.MaxAge = 90 * time.Minute
+	body := loadPayload(t, "report_comment_created.json")
+	assert.Equal(t, http.StatusNoContent, deliver(handler, "report_comment_created", body, Sign(testSecret, body)).Code)
+	require.Len(t, *events, 1)
+	assert.Equal(t, polling.EventCommentAdded, (*events)[0].Type)
+}